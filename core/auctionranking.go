@@ -1,7 +1,10 @@
 package core
 
 import (
-	"sort"
+	"encoding/json"
+	"time"
+
+	"github.com/cloudx-io/openauction/core/audit"
 )
 
 type CoreBid struct {
@@ -11,61 +14,50 @@ type CoreBid struct {
 	Currency string  `json:"currency"`
 	DealID   string  `json:"deal_id,omitempty"`
 	BidType  string  `json:"bid_type,omitempty"`
+	// ReceivedAt is when the TEE accepted this bid. It's only populated by
+	// callers that want TieBreakByArrival; it plays no role in
+	// TieBreakByBidderHash.
+	ReceivedAt time.Time `json:"received_at,omitempty"`
 }
 
 type CoreRankingResult struct {
 	Ranks         map[string]int      `json:"ranks"`
 	HighestBids   map[string]*CoreBid `json:"highest_bids"`
 	SortedBidders []string            `json:"sorted_bidders"`
+	// ClearingPrice is the price the winner(s) actually pay. Every strategy
+	// sets it; for FirstPriceStrategy it equals the winning bid.
+	ClearingPrice float64 `json:"clearing_price,omitempty"`
+	// ExcludedBidders lists bidders whose highest bid fell below the
+	// strategy's reserve price. They're left out of Ranks/HighestBids/
+	// SortedBidders but are still recorded here, and in the audit
+	// transcript, so they can be distinguished from bidders who simply lost.
+	ExcludedBidders []string `json:"excluded_bidders,omitempty"`
 }
 
+// RankCoreBids ranks bids using FirstPriceStrategy with no reserve price and
+// bidder-ID-hash tie-breaking. It exists for backward compatibility; callers
+// that need second-price/Vickrey semantics, a reserve price, or
+// arrival-order tie-breaking should construct the relevant AuctionStrategy
+// directly and call its Rank method.
 func RankCoreBids(bids []CoreBid) *CoreRankingResult {
-	if len(bids) == 0 {
-		return &CoreRankingResult{
-			Ranks:         make(map[string]int),
-			HighestBids:   make(map[string]*CoreBid),
-			SortedBidders: make([]string, 0),
-		}
-	}
-
-	type BidEntry struct {
-		bidder string
-		bid    *CoreBid
-	}
-
-	bidderMap := make(map[string]*CoreBid)
-	for i := range bids {
-		bid := &bids[i]
-		existing, exists := bidderMap[bid.Bidder]
-		if !exists || bid.Price > existing.Price {
-			bidderMap[bid.Bidder] = bid
-		}
-	}
-
-	entries := make([]BidEntry, 0, len(bidderMap))
-	for bidder, bid := range bidderMap {
-		entries = append(entries, BidEntry{
-			bidder: bidder,
-			bid:    bid,
-		})
-	}
-
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].bid.Price > entries[j].bid.Price
-	})
+	return NewFirstPriceStrategy(StrategyConfig{}).Rank(bids)
+}
 
-	result := &CoreRankingResult{
-		Ranks:         make(map[string]int, len(entries)),
-		HighestBids:   make(map[string]*CoreBid, len(entries)),
-		SortedBidders: make([]string, len(entries)),
+// recordRankingResult writes a KindRankingResult entry to log, so losing
+// bidders can later challenge whether the announced ranking was computed
+// over exactly the bids they expect. log is the caller's round transcript;
+// a nil log (what RankCoreBidsWithOptions callers that don't care pass)
+// makes this a no-op. RankCoreBids itself never calls this.
+func recordRankingResult(result *CoreRankingResult, log *audit.Log) {
+	if log == nil {
+		return
 	}
-
-	for rank, entry := range entries {
-		rankValue := rank + 1
-		result.Ranks[entry.bidder] = rankValue
-		result.HighestBids[entry.bidder] = entry.bid
-		result.SortedBidders[rank] = entry.bidder
+	payload, err := json.Marshal(struct {
+		SortedBidders   []string `json:"sorted_bidders"`
+		ExcludedBidders []string `json:"excluded_bidders"`
+	}{result.SortedBidders, result.ExcludedBidders})
+	if err != nil {
+		return
 	}
-
-	return result
+	log.Append(audit.KindRankingResult, payload)
 }
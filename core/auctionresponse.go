@@ -0,0 +1,166 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/cloudx-io/openauction/core/audit"
+)
+
+// ResponseOptions controls how much detail RankCoreBidsWithOptions (and the
+// enclave handler that wraps it) includes in the result envelope, so
+// integrators can get a verbose audit view during testing and a minimal
+// production response without code changes.
+type ResponseOptions struct {
+	// IncludeLosingBids adds every ranked bidder's highest bid except the
+	// winner's to the envelope.
+	IncludeLosingBids bool
+	// IncludeExcludedBids adds the highest bid for every bidder excluded by
+	// a reserve price (and, from the enclave, every bid dropped before
+	// ranking: by rate limiting, signature checks, decryption, or
+	// price/token filtering).
+	IncludeExcludedBids bool
+	// IncludeDecryptErrors adds decrypt failures supplied by a caller that
+	// decrypts bids before ranking them; core itself never decrypts
+	// anything, so this is a no-op unless the caller populates it.
+	IncludeDecryptErrors bool
+	// ReturnSealedPayloads echoes the original ciphertext blobs back on the
+	// envelope for audit, populated by the same kind of caller.
+	ReturnSealedPayloads bool
+	// RedactBidderIDs replaces every bidder ID in the envelope with a salted
+	// hash instead of the plaintext ID.
+	RedactBidderIDs bool
+	// RedactionSalt is mixed into the bidder-ID hash when RedactBidderIDs is
+	// set, so the mapping can't be brute-forced from a known bidder list
+	// without it.
+	RedactionSalt string
+}
+
+// AuctionResultEnvelopeVersion is bumped whenever a field is added to
+// AuctionResultEnvelope. Fields are additive only, so a consumer built
+// against an older version can keep ignoring ones it doesn't recognize.
+const AuctionResultEnvelopeVersion = 1
+
+// AuctionResultEnvelope is the versioned, ResponseOptions-shaped wrapper
+// RankCoreBidsWithOptions (and the enclave's bid-ingress handler) return
+// instead of a bare CoreRankingResult.
+type AuctionResultEnvelope struct {
+	Version int                `json:"version"`
+	Result  *CoreRankingResult `json:"result"`
+
+	// LosingBids is set only when ResponseOptions.IncludeLosingBids is set.
+	LosingBids []CoreBid `json:"losing_bids,omitempty"`
+	// ExcludedBids is set only when ResponseOptions.IncludeExcludedBids is
+	// set.
+	ExcludedBids []CoreBid `json:"excluded_bids,omitempty"`
+	// DecryptErrors is set only when ResponseOptions.IncludeDecryptErrors is
+	// set and the caller populates it.
+	DecryptErrors []string `json:"decrypt_errors,omitempty"`
+	// SealedPayloads is set only when ResponseOptions.ReturnSealedPayloads
+	// is set and the caller populates it.
+	SealedPayloads []EncryptedBidPrice `json:"sealed_payloads,omitempty"`
+}
+
+// RankCoreBidsWithOptions ranks bids the same way RankCoreBids does, then
+// wraps the result in an AuctionResultEnvelope shaped by opts. Options that
+// need information core doesn't have (decrypt errors, sealed payloads) are
+// left zero-valued for the caller to fill in on the returned envelope. log
+// is the caller's transcript for this round (nil disables auditing); unlike
+// a package-level log, a distinct *audit.Log per call lets concurrently
+// running rounds keep separate transcripts.
+func RankCoreBidsWithOptions(bids []CoreBid, opts ResponseOptions, log *audit.Log) *AuctionResultEnvelope {
+	result := RankCoreBids(bids)
+	recordRankingResult(result, log)
+	return buildEnvelope(bids, result, opts)
+}
+
+func buildEnvelope(bids []CoreBid, result *CoreRankingResult, opts ResponseOptions) *AuctionResultEnvelope {
+	envelope := &AuctionResultEnvelope{
+		Version: AuctionResultEnvelopeVersion,
+		Result:  result,
+	}
+
+	if opts.IncludeLosingBids {
+		for _, bidder := range result.SortedBidders[min(1, len(result.SortedBidders)):] {
+			envelope.LosingBids = append(envelope.LosingBids, *result.HighestBids[bidder])
+		}
+	}
+
+	if opts.IncludeExcludedBids && len(result.ExcludedBidders) > 0 {
+		highest := highestBidByBidder(bids)
+		for _, bidder := range result.ExcludedBidders {
+			if bid, ok := highest[bidder]; ok {
+				envelope.ExcludedBids = append(envelope.ExcludedBids, *bid)
+			}
+		}
+	}
+
+	if opts.RedactBidderIDs {
+		redactEnvelopeBidderIDs(envelope, opts.RedactionSalt)
+	}
+
+	return envelope
+}
+
+// highestBidByBidder dedupes bids to each bidder's highest price, the same
+// rule prepareEntries applies, so excluded-bidder lookups stay consistent
+// with what ranking itself used to decide who was excluded.
+func highestBidByBidder(bids []CoreBid) map[string]*CoreBid {
+	highest := make(map[string]*CoreBid, len(bids))
+	for i := range bids {
+		bid := &bids[i]
+		if existing, ok := highest[bid.Bidder]; !ok || bid.Price > existing.Price {
+			highest[bid.Bidder] = bid
+		}
+	}
+	return highest
+}
+
+// RedactBidderID hashes bidder together with salt (SHA-256, truncated to 8
+// bytes, hex-encoded). Callers outside this package that add their own
+// bidder-ID-bearing records to an envelope (e.g. the enclave, for bids it
+// excluded before ranking ever ran) use this so their redaction matches
+// buildEnvelope's.
+func RedactBidderID(salt, bidder string) string {
+	sum := sha256.Sum256([]byte(salt + bidder))
+	return hex.EncodeToString(sum[:8])
+}
+
+func redactEnvelopeBidderIDs(envelope *AuctionResultEnvelope, salt string) {
+	if envelope.Result != nil {
+		r := envelope.Result
+		redactedRanks := make(map[string]int, len(r.Ranks))
+		redactedBids := make(map[string]*CoreBid, len(r.HighestBids))
+		for bidder, rank := range r.Ranks {
+			redactedRanks[RedactBidderID(salt, bidder)] = rank
+		}
+		for bidder, bid := range r.HighestBids {
+			redactedBidder := RedactBidderID(salt, bidder)
+			redacted := *bid
+			redacted.Bidder = redactedBidder
+			redactedBids[redactedBidder] = &redacted
+		}
+		redactedSorted := make([]string, len(r.SortedBidders))
+		for i, bidder := range r.SortedBidders {
+			redactedSorted[i] = RedactBidderID(salt, bidder)
+		}
+		redactedExcluded := make([]string, len(r.ExcludedBidders))
+		for i, bidder := range r.ExcludedBidders {
+			redactedExcluded[i] = RedactBidderID(salt, bidder)
+		}
+		envelope.Result = &CoreRankingResult{
+			Ranks:           redactedRanks,
+			HighestBids:     redactedBids,
+			SortedBidders:   redactedSorted,
+			ClearingPrice:   r.ClearingPrice,
+			ExcludedBidders: redactedExcluded,
+		}
+	}
+
+	for i := range envelope.LosingBids {
+		envelope.LosingBids[i].Bidder = RedactBidderID(salt, envelope.LosingBids[i].Bidder)
+	}
+	for i := range envelope.ExcludedBids {
+		envelope.ExcludedBids[i].Bidder = RedactBidderID(salt, envelope.ExcludedBids[i].Bidder)
+	}
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/peterldowns/testy/check"
+
+	"github.com/cloudx-io/openauction/core/audit"
+)
+
+func TestRankCoreBidsWithOptions_Default(t *testing.T) {
+	bids := []CoreBid{
+		{ID: "bid_a_001", Bidder: "bidder_a", Price: 2.50},
+		{ID: "bid_b_001", Bidder: "bidder_b", Price: 2.25},
+	}
+
+	envelope := RankCoreBidsWithOptions(bids, ResponseOptions{}, nil)
+
+	check.Equal(t, AuctionResultEnvelopeVersion, envelope.Version)
+	check.Equal(t, "bidder_a", envelope.Result.SortedBidders[0])
+	check.Equal(t, 0, len(envelope.LosingBids))
+	check.Equal(t, 0, len(envelope.ExcludedBids))
+}
+
+func TestRankCoreBidsWithOptions_IncludeLosingBids(t *testing.T) {
+	bids := []CoreBid{
+		{ID: "bid_a_001", Bidder: "bidder_a", Price: 2.75},
+		{ID: "bid_b_001", Bidder: "bidder_b", Price: 2.50},
+		{ID: "bid_c_001", Bidder: "bidder_c", Price: 2.25},
+	}
+
+	envelope := RankCoreBidsWithOptions(bids, ResponseOptions{IncludeLosingBids: true}, nil)
+
+	check.Equal(t, 2, len(envelope.LosingBids))
+	check.Equal(t, "bidder_b", envelope.LosingBids[0].Bidder)
+	check.Equal(t, "bidder_c", envelope.LosingBids[1].Bidder)
+}
+
+func TestRankCoreBidsWithOptions_IncludeExcludedBids(t *testing.T) {
+	bids := []CoreBid{
+		{ID: "bid_a_001", Bidder: "bidder_a", Price: 5.00},
+		{ID: "bid_b_001", Bidder: "bidder_b", Price: 0.50},
+	}
+
+	strategy := NewFirstPriceStrategy(StrategyConfig{ReservePrice: 1.00})
+	envelope := buildEnvelope(bids, strategy.Rank(bids), ResponseOptions{IncludeExcludedBids: true})
+
+	check.Equal(t, 1, len(envelope.ExcludedBids))
+	check.Equal(t, "bidder_b", envelope.ExcludedBids[0].Bidder)
+	check.Equal(t, 0.50, envelope.ExcludedBids[0].Price)
+}
+
+func TestRankCoreBidsWithOptions_RedactBidderIDs(t *testing.T) {
+	bids := []CoreBid{
+		{ID: "bid_a_001", Bidder: "bidder_a", Price: 2.75},
+		{ID: "bid_b_001", Bidder: "bidder_b", Price: 2.50},
+	}
+
+	envelope := RankCoreBidsWithOptions(bids, ResponseOptions{
+		IncludeLosingBids: true,
+		RedactBidderIDs:   true,
+		RedactionSalt:     "auction-42-salt",
+	}, nil)
+
+	wantWinner := RedactBidderID("auction-42-salt", "bidder_a")
+	wantLoser := RedactBidderID("auction-42-salt", "bidder_b")
+
+	check.Equal(t, wantWinner, envelope.Result.SortedBidders[0])
+	check.Equal(t, wantLoser, envelope.LosingBids[0].Bidder)
+	check.NotEqual(t, "bidder_a", envelope.Result.SortedBidders[0])
+}
+
+func TestRankCoreBidsWithOptions_RecordsRankingResultOnProvidedLog(t *testing.T) {
+	bids := []CoreBid{
+		{ID: "bid_a_001", Bidder: "bidder_a", Price: 2.50},
+		{ID: "bid_b_001", Bidder: "bidder_b", Price: 2.25},
+	}
+
+	log := audit.NewLog("round-1", nil)
+	RankCoreBidsWithOptions(bids, ResponseOptions{}, log)
+
+	check.Equal(t, 1, log.Len())
+}
+
+func TestRankCoreBidsWithOptions_NoFlagsOmitsEverything(t *testing.T) {
+	bids := []CoreBid{
+		{ID: "bid_a_001", Bidder: "bidder_a", Price: 5.00},
+		{ID: "bid_b_001", Bidder: "bidder_b", Price: 0.50},
+	}
+
+	strategy := NewFirstPriceStrategy(StrategyConfig{ReservePrice: 1.00})
+	envelope := buildEnvelope(bids, strategy.Rank(bids), ResponseOptions{})
+
+	check.Equal(t, 0, len(envelope.LosingBids))
+	check.Equal(t, 0, len(envelope.ExcludedBids))
+	check.Equal(t, 0, len(envelope.DecryptErrors))
+	check.Equal(t, 0, len(envelope.SealedPayloads))
+}
@@ -0,0 +1,295 @@
+package core
+
+import (
+	"crypto/sha256"
+	"math"
+	"sort"
+)
+
+// TieBreakPolicy decides ordering between two bids quoting the same price,
+// which happens often once prices are quantized to whole cents.
+type TieBreakPolicy int
+
+const (
+	// TieBreakByBidderHash orders tied bids by a deterministic hash of the
+	// bidder ID. It's the default: it needs nothing beyond CoreBid.Bidder
+	// and gives the same ordering no matter what order bids arrived in.
+	TieBreakByBidderHash TieBreakPolicy = iota
+	// TieBreakByArrival orders tied bids by CoreBid.ReceivedAt, earliest
+	// first.
+	TieBreakByArrival
+)
+
+// StrategyConfig holds the knobs shared by every AuctionStrategy
+// implementation in this package.
+type StrategyConfig struct {
+	// ReservePrice excludes any bidder whose highest bid is strictly below
+	// it from ranking. Zero (the default) disables the reserve.
+	ReservePrice float64
+	// TieBreak selects how same-price bids are ordered. Zero value is
+	// TieBreakByBidderHash.
+	TieBreak TieBreakPolicy
+	// K is the number of winning slots for KthPriceStrategy; unused by the
+	// other strategies.
+	K int
+}
+
+// AuctionStrategy computes a ranking and clearing price over a set of bids.
+// Implementations dedupe to each bidder's highest bid, drop bids below any
+// configured reserve price into ExcludedBidders, and assign ranks to the
+// survivors according to the strategy's pricing rule.
+type AuctionStrategy interface {
+	Rank(bids []CoreBid) *CoreRankingResult
+}
+
+// bidEntry pairs a bidder with their highest surviving bid.
+type bidEntry struct {
+	bidder string
+	bid    *CoreBid
+}
+
+// prepareEntries dedupes bids to one per bidder (keeping the highest price),
+// splits out bidders below reserve, and sorts the remainder highest-price
+// first using tieBreak to order ties. sortEntriesByPriceDesc's bitonic
+// network visits a fixed sequence of index pairs whose shape depends only
+// on len(entries), never on how the prices compare, unlike sort.Slice. The
+// dedup and reserve comparisons above it still branch directly on
+// bid.Price, each compare/swap in the network still branches on its own
+// result, and the network's own size depends on len(entries) - itself a
+// function of how many bids cleared reserve - so prepareEntries is not
+// constant-time; only the order in which the sort compares entries is fixed.
+func prepareEntries(bids []CoreBid, reserve float64, tieBreak TieBreakPolicy) (entries []bidEntry, excluded []string) {
+	bidderMap := make(map[string]*CoreBid)
+	for i := range bids {
+		bid := &bids[i]
+		existing, exists := bidderMap[bid.Bidder]
+		if !exists || bid.Price > existing.Price {
+			bidderMap[bid.Bidder] = bid
+		}
+	}
+
+	excludedSet := make(map[string]bool)
+	for bidder, bid := range bidderMap {
+		if bid.Price < reserve {
+			excludedSet[bidder] = true
+		}
+	}
+
+	entries = make([]bidEntry, 0, len(bidderMap)-len(excludedSet))
+	for bidder, bid := range bidderMap {
+		if excludedSet[bidder] {
+			continue
+		}
+		entries = append(entries, bidEntry{bidder: bidder, bid: bid})
+	}
+
+	excluded = make([]string, 0, len(excludedSet))
+	for bidder := range excludedSet {
+		excluded = append(excluded, bidder)
+	}
+	sort.Strings(excluded)
+
+	sortEntriesByPriceDesc(entries, tieBreak)
+
+	return entries, excluded
+}
+
+func tieBreakLess(a, b bidEntry, policy TieBreakPolicy) bool {
+	if policy == TieBreakByArrival {
+		return a.bid.ReceivedAt.Before(b.bid.ReceivedAt)
+	}
+	return bidderHash(a.bidder) < bidderHash(b.bidder)
+}
+
+func bidderHash(bidder string) string {
+	sum := sha256.Sum256([]byte(bidder))
+	return string(sum[:])
+}
+
+// entryFinalLess reports whether a belongs before b in the final ranking:
+// higher price first, falling back to tieBreak when the prices are equal.
+// It's the old price-only sort.Slice comparator plus the tie-break, named
+// so the bitonic network below can use it directly as its ascending
+// relation instead of sorting ascending-by-price and reversing.
+func entryFinalLess(a, b bidEntry, tieBreak TieBreakPolicy) bool {
+	if a.bid.Price != b.bid.Price {
+		return a.bid.Price > b.bid.Price
+	}
+	return tieBreakLess(a, b, tieBreak)
+}
+
+// sortEntriesByPriceDesc orders entries highest-price first (ties broken by
+// tieBreak) using a bitonic sorting network padded to the next power of two.
+// A comparison sort such as sort.Slice takes a different number of
+// comparisons, and recurses into different branches, depending on how the
+// input happens to compare; a bitonic network instead runs a fixed sequence
+// of compare/swap stages determined entirely by the padded slice length, so
+// which pairs it compares depends only on len(entries). Its running time is
+// not fully independent of the prices: a compare/swap only swaps when the
+// pair is out of order, and tie-break hashing only runs for equal prices.
+// len(entries) itself is not hidden:
+// it's the count of bids that cleared reserve, and callers that want the
+// reserve/dedup outcome itself to be unobservable need to pad to a fixed
+// maximum bidder count rather than to nextPowerOfTwo(len(entries)).
+func sortEntriesByPriceDesc(entries []bidEntry, tieBreak TieBreakPolicy) {
+	n := len(entries)
+	if n < 2 {
+		return
+	}
+
+	padded := nextPowerOfTwo(n)
+	work := make([]bidEntry, padded)
+	copy(work, entries)
+	// Sentinels carry -Inf prices, so entryFinalLess always orders every
+	// real entry ahead of them regardless of tieBreak.
+	for i := n; i < padded; i++ {
+		work[i] = bidEntry{bid: &CoreBid{Price: math.Inf(-1)}}
+	}
+
+	bitonicSort(work, tieBreak)
+
+	copy(entries, work[:n])
+}
+
+// bitonicSort orders a slice whose length is a power of two by
+// entryFinalLess, using Batcher's bitonic sorting network: a fixed sequence
+// of log2(n)*(log2(n)+1)/2 compare/swap stages whose stage boundaries depend
+// only on len(a).
+func bitonicSort(a []bidEntry, tieBreak TieBreakPolicy) {
+	n := len(a)
+	for k := 2; k <= n; k <<= 1 {
+		for j := k >> 1; j > 0; j >>= 1 {
+			for i := 0; i < n; i++ {
+				l := i ^ j
+				if l <= i {
+					continue
+				}
+				ascending := i&k == 0
+				compareAndSwap(a, i, l, ascending, tieBreak)
+			}
+		}
+	}
+}
+
+func compareAndSwap(a []bidEntry, i, l int, ascending bool, tieBreak TieBreakPolicy) {
+	outOfOrder := entryFinalLess(a[l], a[i], tieBreak)
+	if !ascending {
+		outOfOrder = !outOfOrder
+	}
+	if outOfOrder {
+		a[i], a[l] = a[l], a[i]
+	}
+}
+
+// nextPowerOfTwo returns the smallest power of two >= n.
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// buildResult assigns ranks 1..len(entries) in order and records excluded
+// bidders. Writing the audit hook is RankCoreBidsWithOptions's job, not
+// this one's: Rank is called directly by strategy-level tests and other
+// callers that never reach a real auction round, so logging here would
+// transcript every one of those as if it were a real round.
+func buildResult(entries []bidEntry, excluded []string, clearingPrice float64) *CoreRankingResult {
+	result := &CoreRankingResult{
+		Ranks:           make(map[string]int, len(entries)),
+		HighestBids:     make(map[string]*CoreBid, len(entries)),
+		SortedBidders:   make([]string, len(entries)),
+		ClearingPrice:   clearingPrice,
+		ExcludedBidders: excluded,
+	}
+
+	for rank, entry := range entries {
+		rankValue := rank + 1
+		result.Ranks[entry.bidder] = rankValue
+		result.HighestBids[entry.bidder] = entry.bid
+		result.SortedBidders[rank] = entry.bidder
+	}
+
+	return result
+}
+
+// FirstPriceStrategy ranks bidders by their highest bid and charges the
+// winner exactly what they bid. This is the auction's original behavior.
+type FirstPriceStrategy struct {
+	Config StrategyConfig
+}
+
+func NewFirstPriceStrategy(cfg StrategyConfig) *FirstPriceStrategy {
+	return &FirstPriceStrategy{Config: cfg}
+}
+
+func (s *FirstPriceStrategy) Rank(bids []CoreBid) *CoreRankingResult {
+	entries, excluded := prepareEntries(bids, s.Config.ReservePrice, s.Config.TieBreak)
+	if len(entries) == 0 {
+		return buildResult(entries, excluded, 0)
+	}
+	return buildResult(entries, excluded, entries[0].bid.Price)
+}
+
+// SecondPriceStrategy (Vickrey auction) ranks bidders the same way as
+// FirstPriceStrategy, but the winner's ClearingPrice is the second-highest
+// bid rather than their own. With a single bidder, the clearing price falls
+// back to the reserve price (or zero, if none was configured), since there's
+// no second bid to clear against.
+type SecondPriceStrategy struct {
+	Config StrategyConfig
+}
+
+func NewSecondPriceStrategy(cfg StrategyConfig) *SecondPriceStrategy {
+	return &SecondPriceStrategy{Config: cfg}
+}
+
+func (s *SecondPriceStrategy) Rank(bids []CoreBid) *CoreRankingResult {
+	entries, excluded := prepareEntries(bids, s.Config.ReservePrice, s.Config.TieBreak)
+	if len(entries) == 0 {
+		return buildResult(entries, excluded, 0)
+	}
+	if len(entries) == 1 {
+		return buildResult(entries, excluded, max(s.Config.ReservePrice, 0))
+	}
+	return buildResult(entries, excluded, entries[1].bid.Price)
+}
+
+// KthPriceStrategy generalizes SecondPriceStrategy to multi-unit auctions:
+// the top Config.K bidders each win a unit, and all of them pay the same
+// clearing price, set by the (K+1)-th highest bid. If fewer than K+1 bidders
+// remain after the reserve filter, the clearing price falls back to the
+// lowest surviving winning bid (there's no K+1-th bid to clear against).
+type KthPriceStrategy struct {
+	Config StrategyConfig
+}
+
+func NewKthPriceStrategy(cfg StrategyConfig) *KthPriceStrategy {
+	return &KthPriceStrategy{Config: cfg}
+}
+
+func (s *KthPriceStrategy) Rank(bids []CoreBid) *CoreRankingResult {
+	entries, excluded := prepareEntries(bids, s.Config.ReservePrice, s.Config.TieBreak)
+	if len(entries) == 0 {
+		return buildResult(entries, excluded, 0)
+	}
+
+	k := s.Config.K
+	if k <= 0 {
+		k = 1
+	}
+
+	var clearingPrice float64
+	if k < len(entries) {
+		clearingPrice = entries[k].bid.Price
+	} else {
+		winners := k
+		if winners > len(entries) {
+			winners = len(entries)
+		}
+		clearingPrice = entries[winners-1].bid.Price
+	}
+
+	return buildResult(entries, excluded, clearingPrice)
+}
@@ -0,0 +1,267 @@
+// Package audit maintains a tamper-evident, append-only transcript of the
+// material events in an auction round (tokens issued, bids received,
+// decryption outcomes, ranking results) so that an external party can later
+// verify that a specific entry was included in a specific round without
+// seeing any other bidder's data.
+//
+// Entries are hash-chained (each entry's Hash commits to the previous
+// entry's Hash) and are also leaves of a Merkle tree. Commit() seals the
+// current set of entries behind a single root; ProveInclusion() returns the
+// sibling path a bidder needs to check their entry against that root.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AuditEntry is one record in the transcript.
+type AuditEntry struct {
+	Seq         uint64
+	PrevHash    [32]byte
+	Kind        string
+	PayloadHash [32]byte
+	Timestamp   time.Time
+	Hash        [32]byte
+}
+
+// Event kinds recorded by the hooks in this package's callers.
+const (
+	KindTokenIssued    = "token_issued"
+	KindBidReceived    = "bid_received"
+	KindDecryptSuccess = "decrypt_success"
+	KindDecryptFailure = "decrypt_failure"
+	KindRankingResult  = "ranking_result"
+)
+
+func hashEntry(seq uint64, prevHash [32]byte, kind string, payloadHash [32]byte, ts time.Time) [32]byte {
+	h := sha256.New()
+	var seqBuf [8]byte
+	binary.BigEndian.PutUint64(seqBuf[:], seq)
+	h.Write(seqBuf[:])
+	h.Write(prevHash[:])
+	h.Write([]byte(kind))
+	h.Write(payloadHash[:])
+	tsBuf, _ := ts.UTC().MarshalBinary()
+	h.Write(tsBuf)
+
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// Signer produces a signature over an attestation's signed bytes. Passing a
+// nil Signer to NewLog leaves Attestation.Signature empty, which is fine for
+// tests and for callers that attest via a transport-level signature instead.
+type Signer interface {
+	Sign(message []byte) ([]byte, error)
+}
+
+// Attestation is the enclave-signed summary produced by Commit.
+type Attestation struct {
+	Root       [32]byte
+	RoundID    string
+	EntryCount uint64
+	Signature  []byte
+}
+
+// SignedBytes returns the canonical byte representation that Signature signs
+// over, so external verifiers can reconstruct it from a published
+// Attestation.
+func (a *Attestation) SignedBytes() []byte {
+	buf := make([]byte, 0, 32+8+len(a.RoundID))
+	buf = append(buf, a.Root[:]...)
+	var countBuf [8]byte
+	binary.BigEndian.PutUint64(countBuf[:], a.EntryCount)
+	buf = append(buf, countBuf[:]...)
+	buf = append(buf, []byte(a.RoundID)...)
+	return buf
+}
+
+// MerklePathNode is one step of an inclusion proof: the sibling hash at that
+// level, and whether the sibling sits to the left of the node being proved.
+type MerklePathNode struct {
+	Hash [32]byte
+	Left bool
+}
+
+// Log is an append-only, hash-chained auction transcript for a single
+// auction round. It is safe for concurrent use.
+type Log struct {
+	mu      sync.Mutex
+	roundID string
+	signer  Signer
+	entries []*AuditEntry
+}
+
+// NewLog creates an empty transcript for the given round. signer may be nil.
+func NewLog(roundID string, signer Signer) *Log {
+	return &Log{roundID: roundID, signer: signer}
+}
+
+// Append records a new entry at the end of the transcript and returns it.
+// payload is hashed rather than stored verbatim so the transcript never
+// holds plaintext bid prices or other sensitive data.
+func (l *Log) Append(kind string, payload []byte) *AuditEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var prevHash [32]byte
+	if n := len(l.entries); n > 0 {
+		prevHash = l.entries[n-1].Hash
+	}
+
+	seq := uint64(len(l.entries))
+	payloadHash := sha256.Sum256(payload)
+	ts := time.Now()
+
+	entry := &AuditEntry{
+		Seq:         seq,
+		PrevHash:    prevHash,
+		Kind:        kind,
+		PayloadHash: payloadHash,
+		Timestamp:   ts,
+		Hash:        hashEntry(seq, prevHash, kind, payloadHash, ts),
+	}
+	l.entries = append(l.entries, entry)
+	return entry
+}
+
+// Len returns the number of entries recorded so far.
+func (l *Log) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.entries)
+}
+
+// Commit builds a Merkle tree over every entry's Hash and returns a signed
+// attestation over (root, round_id, entry_count). Calling Commit again after
+// more entries are appended produces a new attestation over the larger set;
+// it does not prevent further appends.
+func (l *Log) Commit() (*Attestation, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.entries) == 0 {
+		return nil, errors.New("audit: cannot commit an empty transcript")
+	}
+
+	leaves := make([][32]byte, len(l.entries))
+	for i, e := range l.entries {
+		leaves[i] = e.Hash
+	}
+	root := merkleRoot(leaves)
+
+	att := &Attestation{
+		Root:       root,
+		RoundID:    l.roundID,
+		EntryCount: uint64(len(l.entries)),
+	}
+
+	if l.signer != nil {
+		sig, err := l.signer.Sign(att.SignedBytes())
+		if err != nil {
+			return nil, fmt.Errorf("audit: failed to sign attestation: %w", err)
+		}
+		att.Signature = sig
+	}
+
+	return att, nil
+}
+
+// ProveInclusion returns the sibling path for the entry at seq, which a
+// bidder can combine with their own entry's payload hash and a previously
+// published root to verify inclusion via VerifyInclusion.
+func (l *Log) ProveInclusion(seq uint64) ([]MerklePathNode, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if seq >= uint64(len(l.entries)) {
+		return nil, fmt.Errorf("audit: no entry with seq %d", seq)
+	}
+
+	leaves := make([][32]byte, len(l.entries))
+	for i, e := range l.entries {
+		leaves[i] = e.Hash
+	}
+	return merklePath(leaves, int(seq)), nil
+}
+
+// VerifyInclusion checks that entry.Hash, combined with path, reproduces
+// root. It does not need a Log at all, so bidders can run it independently
+// given only the published root and their own entry.
+func VerifyInclusion(root [32]byte, entry *AuditEntry, path []MerklePathNode) bool {
+	cur := entry.Hash
+	for _, node := range path {
+		if node.Left {
+			cur = hashPair(node.Hash, cur)
+		} else {
+			cur = hashPair(cur, node.Hash)
+		}
+	}
+	return cur == root
+}
+
+func hashPair(left, right [32]byte) [32]byte {
+	h := sha256.New()
+	h.Write(left[:])
+	h.Write(right[:])
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// merkleRoot builds a binary Merkle tree over leaves, duplicating the final
+// node at each level when the level has an odd count.
+func merkleRoot(leaves [][32]byte) [32]byte {
+	level := leaves
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+	return level[0]
+}
+
+func nextLevel(level [][32]byte) [][32]byte {
+	next := make([][32]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 < len(level) {
+			next = append(next, hashPair(level[i], level[i+1]))
+		} else {
+			next = append(next, hashPair(level[i], level[i]))
+		}
+	}
+	return next
+}
+
+// merklePath walks the same level-by-level construction as merkleRoot,
+// recording the sibling hash at each level for the leaf at index.
+func merklePath(leaves [][32]byte, index int) []MerklePathNode {
+	var path []MerklePathNode
+	level := leaves
+
+	for len(level) > 1 {
+		var sibling [32]byte
+		var left bool
+		if index%2 == 0 {
+			if index+1 < len(level) {
+				sibling = level[index+1]
+			} else {
+				sibling = level[index]
+			}
+			left = false
+		} else {
+			sibling = level[index-1]
+			left = true
+		}
+		path = append(path, MerklePathNode{Hash: sibling, Left: left})
+
+		level = nextLevel(level)
+		index /= 2
+	}
+
+	return path
+}
@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/peterldowns/testy/check"
+)
+
+func TestLog_AppendChainsHashes(t *testing.T) {
+	l := NewLog("round-1", nil)
+
+	e1 := l.Append(KindBidReceived, []byte("bid-1"))
+	e2 := l.Append(KindBidReceived, []byte("bid-2"))
+
+	check.Equal(t, uint64(0), e1.Seq)
+	check.Equal(t, uint64(1), e2.Seq)
+	check.Equal(t, e1.Hash, e2.PrevHash)
+	check.Equal(t, 2, l.Len())
+}
+
+func TestLog_CommitEmptyFails(t *testing.T) {
+	l := NewLog("round-1", nil)
+
+	_, err := l.Commit()
+	check.NotNil(t, err)
+}
+
+func TestLog_ProveInclusion(t *testing.T) {
+	l := NewLog("round-1", nil)
+
+	var entries []*AuditEntry
+	for i := 0; i < 7; i++ {
+		entries = append(entries, l.Append(KindBidReceived, []byte{byte(i)}))
+	}
+
+	att, err := l.Commit()
+	check.NoError(t, err)
+	check.Equal(t, uint64(7), att.EntryCount)
+
+	for _, e := range entries {
+		path, err := l.ProveInclusion(e.Seq)
+		check.NoError(t, err)
+		check.True(t, VerifyInclusion(att.Root, e, path))
+	}
+}
+
+func TestLog_ProveInclusion_UnknownSeq(t *testing.T) {
+	l := NewLog("round-1", nil)
+	l.Append(KindBidReceived, []byte("only-entry"))
+
+	_, err := l.ProveInclusion(5)
+	check.NotNil(t, err)
+}
+
+func TestVerifyInclusion_RejectsWrongEntry(t *testing.T) {
+	l := NewLog("round-1", nil)
+	e0 := l.Append(KindBidReceived, []byte("bid-0"))
+	l.Append(KindBidReceived, []byte("bid-1"))
+
+	att, err := l.Commit()
+	check.NoError(t, err)
+
+	path, err := l.ProveInclusion(e0.Seq)
+	check.NoError(t, err)
+
+	tampered := *e0
+	tampered.PayloadHash[0] ^= 0xFF
+	tampered.Hash = hashEntry(tampered.Seq, tampered.PrevHash, tampered.Kind, tampered.PayloadHash, tampered.Timestamp)
+
+	check.False(t, VerifyInclusion(att.Root, &tampered, path))
+}
+
+type fakeSigner struct{}
+
+func (fakeSigner) Sign(message []byte) ([]byte, error) {
+	out := make([]byte, len(message))
+	copy(out, message)
+	return out, nil
+}
+
+func TestLog_CommitSignsAttestation(t *testing.T) {
+	l := NewLog("round-1", fakeSigner{})
+	l.Append(KindTokenIssued, []byte("token-1"))
+
+	att, err := l.Commit()
+	check.NoError(t, err)
+	check.Equal(t, string(att.SignedBytes()), string(att.Signature))
+}
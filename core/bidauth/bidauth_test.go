@@ -0,0 +1,109 @@
+package bidauth
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterldowns/testy/check"
+
+	"github.com/cloudx-io/openauction/core"
+)
+
+func newSignedEnvelope(t *testing.T, priv ed25519.PrivateKey, nonce uint64) *core.EncryptedBidPrice {
+	t.Helper()
+	env := &core.EncryptedBidPrice{
+		AESKeyEncrypted:  "key",
+		EncryptedPayload: "payload",
+		Nonce:            "nonce",
+		AuctionID:        "auction-1",
+		Timestamp:        time.Now(),
+		BidderNonce:      nonce,
+	}
+	env.Signature = Sign(priv, env)
+	return env
+}
+
+func TestAllowlist_VerifyValidSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	check.NoError(t, err)
+
+	a := NewAllowlist()
+	a.Register("bidder_a", pub)
+
+	env := newSignedEnvelope(t, priv, 1)
+	check.NoError(t, a.Verify("bidder_a", env))
+}
+
+func TestAllowlist_UnknownBidder(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	check.NoError(t, err)
+
+	a := NewAllowlist()
+	env := newSignedEnvelope(t, priv, 1)
+
+	err = a.Verify("bidder_a", env)
+	check.True(t, errors.Is(err, ErrUnknownBidder))
+}
+
+func TestAllowlist_InvalidSignature(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	check.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	check.NoError(t, err)
+
+	a := NewAllowlist()
+	a.Register("bidder_a", pub)
+
+	// Signed by the wrong key.
+	env := newSignedEnvelope(t, otherPriv, 1)
+
+	err = a.Verify("bidder_a", env)
+	check.True(t, errors.Is(err, ErrInvalidSignature))
+}
+
+func TestAllowlist_TamperedEnvelopeFailsVerification(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	check.NoError(t, err)
+
+	a := NewAllowlist()
+	a.Register("bidder_a", pub)
+
+	env := newSignedEnvelope(t, priv, 1)
+	env.EncryptedPayload = "tampered-payload"
+
+	err = a.Verify("bidder_a", env)
+	check.True(t, errors.Is(err, ErrInvalidSignature))
+}
+
+func TestAllowlist_StaleNonceRejected(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	check.NoError(t, err)
+
+	a := NewAllowlist()
+	a.Register("bidder_a", pub)
+
+	first := newSignedEnvelope(t, priv, 5)
+	check.NoError(t, a.Verify("bidder_a", first))
+
+	replay := newSignedEnvelope(t, priv, 5)
+	err = a.Verify("bidder_a", replay)
+	check.True(t, errors.Is(err, ErrStaleNonce))
+
+	older := newSignedEnvelope(t, priv, 3)
+	err = a.Verify("bidder_a", older)
+	check.True(t, errors.Is(err, ErrStaleNonce))
+}
+
+func TestAllowlist_IncreasingNoncesAccepted(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	check.NoError(t, err)
+
+	a := NewAllowlist()
+	a.Register("bidder_a", pub)
+
+	check.NoError(t, a.Verify("bidder_a", newSignedEnvelope(t, priv, 1)))
+	check.NoError(t, a.Verify("bidder_a", newSignedEnvelope(t, priv, 2)))
+	check.NoError(t, a.Verify("bidder_a", newSignedEnvelope(t, priv, 3)))
+}
@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 // EncryptedBidPrice represents encrypted price data using RSA-OAEP-SHA256/AES-256-GCM.
 // Bidders may encrypt their bid prices using a public key provided in the initial bid request,
 // ensuring that prices are only ever decrypted inside the TEE where the auction runs.
@@ -7,6 +9,38 @@ type EncryptedBidPrice struct {
 	AESKeyEncrypted  string `json:"aes_key_encrypted"` // base64-encoded RSA-OAEP encrypted AES key
 	EncryptedPayload string `json:"encrypted_payload"` // base64-encoded AES-GCM encrypted {"price": X}
 	Nonce            string `json:"nonce"`             // base64-encoded GCM nonce (12 bytes)
+
+	// Algorithm names the crypto.Engine tag this envelope was sealed under.
+	// It defaults to the original RSA-OAEP-SHA256+AES-256-GCM scheme when
+	// empty, so bids sealed before this field existed still decrypt.
+	Algorithm string `json:"algorithm,omitempty"`
+	// KeyID identifies which key in the enclave's keystores.KeyStore this
+	// envelope was sealed against, so a bid sealed just before a rotation
+	// can still be decrypted during the rotation window.
+	KeyID string `json:"key_id,omitempty"`
+	// EphemeralPublicKey carries the bidder's ephemeral P-256 point when
+	// Algorithm is the ecies tag, in place of AESKeyEncrypted: the enclave
+	// derives the AES key via ECDH against it instead of RSA-OAEP-unwrapping
+	// AESKeyEncrypted. It is unused for every other algorithm.
+	EphemeralPublicKey string `json:"ephemeral_public_key,omitempty"` // base64-encoded uncompressed P-256 point (65 bytes); compressed points are rejected
+
+	// BidderPublicKey, Signature, AuctionID, Timestamp, and BidderNonce
+	// support the optional core/bidauth signing scheme: when the enclave is
+	// given a bidauth.Allowlist, it verifies Signature against
+	// (AESKeyEncrypted || EncryptedPayload || Nonce || AuctionID || Timestamp)
+	// before attempting decryption, so forged envelopes are rejected before
+	// they cost a single RSA cycle. Verification uses the key registered on
+	// the allowlist for the bid's bidder; BidderPublicKey is not read by it.
+	BidderPublicKey string    `json:"bidder_public_key,omitempty"` // base64-encoded Ed25519 public key
+	Signature       string    `json:"signature,omitempty"`         // base64-encoded Ed25519 signature
+	AuctionID       string    `json:"auction_id,omitempty"`
+	Timestamp       time.Time `json:"timestamp,omitempty"`
+	// BidderNonce is a per-bidder monotonic counter that core/bidauth checks
+	// strictly increases between submissions. It's independent of the
+	// single-use auction token in DecryptedBidPayload.AuctionToken, which
+	// protects against a different kind of replay (re-sending a token that
+	// has already been consumed inside the TEE).
+	BidderNonce uint64 `json:"bidder_nonce,omitempty"`
 }
 
 // DecryptedBidPayload represents the decrypted bid payload structure.
@@ -0,0 +1,63 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudx-io/openauction/core/audit"
+)
+
+// AESCFBLegacyAlgorithmName is the tag for bids sealed with AES-256-CFB
+// before this deployment moved to AES-256-GCM. It is kept enabled for
+// decrypt only; new bids must never be sealed with it, since CFB has no
+// built-in authentication.
+const AESCFBLegacyAlgorithmName = "aes-256-cfb/v1"
+
+type aesCFBLegacyEnvelope struct {
+	EncryptedPayload string `json:"encrypted_payload"`
+	Nonce            string `json:"nonce"` // reused as the CFB initialization vector
+}
+
+// AESCFBLegacyAlgorithm implements Algorithm (and deliberately not
+// EncryptingAlgorithm) for the retired AES-256-CFB scheme.
+type AESCFBLegacyAlgorithm struct{}
+
+func (AESCFBLegacyAlgorithm) Name() string { return AESCFBLegacyAlgorithmName }
+
+// Decrypt implements Algorithm. log is unused: this legacy scheme never
+// audited its own decrypts.
+func (AESCFBLegacyAlgorithm) Decrypt(envelopeJSON []byte, key any, log *audit.Log) ([]byte, error) {
+	aesKey, ok := key.([]byte)
+	if !ok || len(aesKey) != 32 {
+		return nil, fmt.Errorf("crypto: %s needs a 32-byte AES key, got %T", AESCFBLegacyAlgorithmName, key)
+	}
+
+	var env aesCFBLegacyEnvelope
+	if err := json.Unmarshal(envelopeJSON, &env); err != nil {
+		return nil, fmt.Errorf("crypto: failed to parse %s envelope: %w", AESCFBLegacyAlgorithmName, err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(env.EncryptedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to decode encrypted payload: %w", err)
+	}
+	iv, err := base64.StdEncoding.DecodeString(env.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to decode iv: %w", err)
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("crypto: invalid iv length: expected %d bytes, got %d", aes.BlockSize, len(iv))
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to create AES cipher: %w", err)
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCFBDecrypter(block, iv).XORKeyStream(plaintext, ciphertext)
+	return plaintext, nil
+}
@@ -0,0 +1,173 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudx-io/openauction/core/audit"
+)
+
+// ECIESAlgorithmName is the algorithm tag for ECIES over P-256: ephemeral-
+// static ECDH, HKDF-SHA256 to derive an AES-256-GCM key, then AES-256-GCM
+// over the payload. It exists alongside RSAOAEPAlgorithmName for bidders
+// who'd rather not implement RSA-OAEP.
+const ECIESAlgorithmName = "ecies-p256-hkdf-sha256+aes-256-gcm/v1"
+
+type eciesEnvelope struct {
+	EphemeralPublicKey string `json:"ephemeral_public_key"`
+	EncryptedPayload   string `json:"encrypted_payload"`
+	Nonce              string `json:"nonce"`
+}
+
+// ECIESAlgorithm implements Algorithm and EncryptingAlgorithm for ECIES over
+// P-256.
+type ECIESAlgorithm struct{}
+
+func (ECIESAlgorithm) Name() string { return ECIESAlgorithmName }
+
+// Decrypt implements Algorithm. log is unused: this scheme doesn't audit
+// its own decrypts (only RSAOAEPAlgorithm does today).
+func (ECIESAlgorithm) Decrypt(envelopeJSON []byte, key any, log *audit.Log) ([]byte, error) {
+	privateKey, ok := key.(*ecdh.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("crypto: %s needs an *ecdh.PrivateKey, got %T", ECIESAlgorithmName, key)
+	}
+
+	var env eciesEnvelope
+	if err := json.Unmarshal(envelopeJSON, &env); err != nil {
+		return nil, fmt.Errorf("crypto: failed to parse %s envelope: %w", ECIESAlgorithmName, err)
+	}
+
+	ephemeralPubKeyBytes, err := base64.StdEncoding.DecodeString(env.EphemeralPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to decode ephemeral public key: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(env.EncryptedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to decode encrypted payload: %w", err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(env.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to decode nonce: %w", err)
+	}
+
+	ephemeralPubKey, err := ecdh.P256().NewPublicKey(ephemeralPubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: invalid ephemeral public key: %w", err)
+	}
+
+	sharedSecret, err := privateKey.ECDH(ephemeralPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: ECDH exchange failed: %w", err)
+	}
+
+	aesKey, err := hkdfSHA256(sharedSecret, ephemeralPubKeyBytes, []byte("openauction-ecies-v1"), 32)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: key derivation failed: %w", err)
+	}
+
+	aesgcm, err := newGCM(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aesgcm.NonceSize() {
+		return nil, fmt.Errorf("crypto: invalid nonce length: expected %d bytes, got %d", aesgcm.NonceSize(), len(nonce))
+	}
+
+	plaintext, err := aesgcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to decrypt payload: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (ECIESAlgorithm) Encrypt(plaintext []byte, key any) ([]byte, error) {
+	peerPublicKey, ok := key.(*ecdh.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("crypto: %s needs an *ecdh.PublicKey, got %T", ECIESAlgorithmName, key)
+	}
+
+	ephemeralPrivateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to generate ephemeral key pair: %w", err)
+	}
+
+	sharedSecret, err := ephemeralPrivateKey.ECDH(peerPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: ECDH exchange failed: %w", err)
+	}
+
+	ephemeralPubKeyBytes := ephemeralPrivateKey.PublicKey().Bytes()
+	aesKey, err := hkdfSHA256(sharedSecret, ephemeralPubKeyBytes, []byte("openauction-ecies-v1"), 32)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: key derivation failed: %w", err)
+	}
+
+	aesgcm, err := newGCM(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("crypto: failed to generate nonce: %w", err)
+	}
+	ciphertext := aesgcm.Seal(nil, nonce, plaintext, nil)
+
+	return json.Marshal(eciesEnvelope{
+		EphemeralPublicKey: base64.StdEncoding.EncodeToString(ephemeralPubKeyBytes),
+		EncryptedPayload:   base64.StdEncoding.EncodeToString(ciphertext),
+		Nonce:              base64.StdEncoding.EncodeToString(nonce),
+	})
+}
+
+func newGCM(aesKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to create AES cipher: %w", err)
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to create GCM: %w", err)
+	}
+	return aesgcm, nil
+}
+
+// hkdfSHA256 is a minimal HKDF (RFC 5869) implementation over SHA-256, kept
+// local so this package doesn't import golang.org/x/crypto (only the
+// enclave's autocert TLS path does).
+func hkdfSHA256(secret, salt, info []byte, length int) ([]byte, error) {
+	if salt == nil {
+		salt = make([]byte, sha256.Size)
+	}
+
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+
+	var (
+		out  []byte
+		prev []byte
+		ctr  byte = 1
+	)
+	for len(out) < length {
+		expand := hmac.New(sha256.New, prk)
+		expand.Write(prev)
+		expand.Write(info)
+		expand.Write([]byte{ctr})
+		prev = expand.Sum(nil)
+		out = append(out, prev...)
+		ctr++
+		if ctr == 0 {
+			return nil, fmt.Errorf("hkdf: requested length %d too large", length)
+		}
+	}
+
+	return out[:length], nil
+}
@@ -0,0 +1,37 @@
+package crypto
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+
+	"github.com/peterldowns/testy/check"
+)
+
+func TestEngine_ECIESEncryptDecryptRoundTrip(t *testing.T) {
+	privateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	check.NoError(t, err)
+
+	e := NewDefaultEngine()
+
+	envelope, err := e.Encrypt(ECIESAlgorithmName, []byte(`{"price":9.5}`), privateKey.PublicKey())
+	check.NoError(t, err)
+
+	plaintext, err := e.Decrypt(ECIESAlgorithmName, envelope, privateKey, nil)
+	check.NoError(t, err)
+	check.Equal(t, `{"price":9.5}`, string(plaintext))
+}
+
+func TestEngine_ECIESWrongPrivateKeyFails(t *testing.T) {
+	privateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	check.NoError(t, err)
+	wrongKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	check.NoError(t, err)
+
+	e := NewDefaultEngine()
+	envelope, err := e.Encrypt(ECIESAlgorithmName, []byte(`{"price":1}`), privateKey.PublicKey())
+	check.NoError(t, err)
+
+	_, err = e.Decrypt(ECIESAlgorithmName, envelope, wrongKey, nil)
+	check.NotNil(t, err)
+}
@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/peterldowns/testy/check"
+)
+
+func TestEngine_RSAOAEPEncryptDecryptRoundTrip(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	check.NoError(t, err)
+
+	e := NewDefaultEngine()
+
+	envelope, err := e.Encrypt(RSAOAEPAlgorithmName, []byte(`{"price":2.5}`), &privateKey.PublicKey)
+	check.NoError(t, err)
+
+	plaintext, err := e.Decrypt(RSAOAEPAlgorithmName, envelope, privateKey, nil)
+	check.NoError(t, err)
+	check.Equal(t, `{"price":2.5}`, string(plaintext))
+}
+
+func TestEngine_UnknownAlgorithmRejected(t *testing.T) {
+	e := NewDefaultEngine()
+
+	_, err := e.Decrypt("does-not-exist/v1", []byte("{}"), nil, nil)
+	check.NotNil(t, err)
+}
+
+func TestEngine_LegacyAESCFBIsDecryptOnly(t *testing.T) {
+	e := NewDefaultEngine()
+	check.True(t, e.Enabled(AESCFBLegacyAlgorithmName))
+
+	_, err := e.Encrypt(AESCFBLegacyAlgorithmName, []byte("plaintext"), make([]byte, 32))
+	check.NotNil(t, err)
+}
+
+func TestEngine_LegacyAESCFBDecrypts(t *testing.T) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	check.NoError(t, err)
+
+	block, err := aes.NewCipher(key)
+	check.NoError(t, err)
+
+	iv := make([]byte, aes.BlockSize)
+	_, err = rand.Read(iv)
+	check.NoError(t, err)
+
+	plaintext := []byte(`{"price":1.25}`)
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCFBEncrypter(block, iv).XORKeyStream(ciphertext, plaintext)
+
+	envelope, err := json.Marshal(aesCFBLegacyEnvelope{
+		EncryptedPayload: base64.StdEncoding.EncodeToString(ciphertext),
+		Nonce:            base64.StdEncoding.EncodeToString(iv),
+	})
+	check.NoError(t, err)
+
+	e := NewDefaultEngine()
+	decrypted, err := e.Decrypt(AESCFBLegacyAlgorithmName, envelope, key, nil)
+	check.NoError(t, err)
+	check.Equal(t, string(plaintext), string(decrypted))
+}
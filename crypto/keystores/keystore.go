@@ -0,0 +1,65 @@
+// Package keystores abstracts where the enclave's RSA decryption keys come
+// from, so the bid-decryption path can work the same way whether it's
+// backed by a single in-memory keypair or a rotating, file-backed store.
+package keystores
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"regexp"
+)
+
+// rsaKeyBits is the RSA modulus size used for every key this package
+// generates, matching GenerateRSAKeyPair elsewhere in the enclave.
+const rsaKeyBits = 2048
+
+// ErrInvalidKeyID is returned when a kid passed to LocalKeyStore.GetByID
+// doesn't match the format newKeyID generates, which rejects path-traversal
+// attempts (e.g. "../../etc/passwd") read off an unauthenticated bid
+// envelope before they ever reach a filesystem path. MemoryKeyStore.GetByID
+// touches no filesystem and reports any unknown kid with a plain not-found
+// error instead.
+var ErrInvalidKeyID = errors.New("keystores: invalid key id")
+
+// keyIDPattern matches exactly what newKeyID produces: 16 lowercase hex
+// characters (8 random bytes, hex-encoded).
+var keyIDPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// validateKeyID rejects any kid that doesn't match keyIDPattern, so a kid
+// read from attacker-controlled input can't be used to build a filesystem
+// path outside the key store's directory.
+func validateKeyID(kid string) error {
+	if !keyIDPattern.MatchString(kid) {
+		return ErrInvalidKeyID
+	}
+	return nil
+}
+
+// KeyStore is the interface EnclaveServer depends on for RSA decryption
+// keys. GetByID lets a bid sealed just before a rotation still be decrypted
+// during the rotation window, since the envelope records the kid it was
+// sealed against.
+type KeyStore interface {
+	// CurrentKey returns the key new envelopes should be sealed against,
+	// along with its key ID.
+	CurrentKey() (kid string, key *rsa.PrivateKey, err error)
+	// GetByID looks up a (possibly retired) key by its ID.
+	GetByID(kid string) (*rsa.PrivateKey, error)
+	// Rotate generates a new current key without discarding the previous
+	// one, and returns its ID and public half.
+	Rotate() (kid string, publicKey *rsa.PublicKey, err error)
+}
+
+func newKeyID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func generateRSAKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+}
@@ -0,0 +1,90 @@
+package keystores
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/peterldowns/testy/check"
+)
+
+func TestMemoryKeyStore_RotateKeepsOldKeyAccessible(t *testing.T) {
+	ks, err := NewMemoryKeyStore()
+	check.NoError(t, err)
+
+	oldKid, oldKey, err := ks.CurrentKey()
+	check.NoError(t, err)
+
+	newKid, _, err := ks.Rotate()
+	check.NoError(t, err)
+	check.NotEqual(t, oldKid, newKid)
+
+	gotOld, err := ks.GetByID(oldKid)
+	check.NoError(t, err)
+	check.True(t, oldKey.Equal(gotOld))
+
+	curKid, _, err := ks.CurrentKey()
+	check.NoError(t, err)
+	check.Equal(t, newKid, curKid)
+}
+
+func TestMemoryKeyStore_GetByIDUnknown(t *testing.T) {
+	ks, err := NewMemoryKeyStore()
+	check.NoError(t, err)
+
+	_, err = ks.GetByID("does-not-exist")
+	check.NotNil(t, err)
+}
+
+func TestLocalKeyStore_RotateAndReopen(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+
+	ks, err := NewLocalKeyStore(dir)
+	check.NoError(t, err)
+
+	kid, _, err := ks.CurrentKey()
+	check.NoError(t, err)
+
+	reopened, err := NewLocalKeyStore(dir)
+	check.NoError(t, err)
+
+	reopenedKid, _, err := reopened.CurrentKey()
+	check.NoError(t, err)
+	check.Equal(t, kid, reopenedKid)
+}
+
+func TestLocalKeyStore_RotateGeneratesNewKey(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+
+	ks, err := NewLocalKeyStore(dir)
+	check.NoError(t, err)
+
+	firstKid, _, err := ks.CurrentKey()
+	check.NoError(t, err)
+
+	secondKid, _, err := ks.Rotate()
+	check.NoError(t, err)
+	check.NotEqual(t, firstKid, secondKid)
+
+	// The retired key is still readable during the rotation window.
+	_, err = ks.GetByID(firstKid)
+	check.NoError(t, err)
+}
+
+func TestLocalKeyStore_GetByIDRejectsPathTraversal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+
+	ks, err := NewLocalKeyStore(dir)
+	check.NoError(t, err)
+
+	for _, kid := range []string{
+		"../../../etc/passwd",
+		"CURRENT",
+		"foo/bar",
+		"",
+		"not-hex!!",
+	} {
+		_, err := ks.GetByID(kid)
+		check.True(t, errors.Is(err, ErrInvalidKeyID))
+	}
+}
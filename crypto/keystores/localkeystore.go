@@ -0,0 +1,110 @@
+package keystores
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// LocalKeyStore persists each generated key as a PEM-encoded PKCS#1 file
+// under a base directory, and tracks the current key ID in a CURRENT file
+// alongside them, so keys survive a restart and a rotation window can span
+// process lifetimes.
+type LocalKeyStore struct {
+	mu      sync.RWMutex
+	dir     string
+	current string
+}
+
+// NewLocalKeyStore opens (or initializes) a LocalKeyStore rooted at dir. If
+// dir already holds a CURRENT file from a previous run, that key becomes the
+// current key; otherwise a fresh RSA-2048 key is generated.
+func NewLocalKeyStore(dir string) (*LocalKeyStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("keystores: failed to create key directory: %w", err)
+	}
+
+	ks := &LocalKeyStore{dir: dir}
+
+	if data, err := os.ReadFile(ks.currentPath()); err == nil {
+		ks.current = strings.TrimSpace(string(data))
+		return ks, nil
+	}
+
+	if _, _, err := ks.Rotate(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+func (ks *LocalKeyStore) keyPath(kid string) string {
+	return filepath.Join(ks.dir, kid+".pem")
+}
+
+func (ks *LocalKeyStore) currentPath() string {
+	return filepath.Join(ks.dir, "CURRENT")
+}
+
+func (ks *LocalKeyStore) CurrentKey() (string, *rsa.PrivateKey, error) {
+	ks.mu.RLock()
+	kid := ks.current
+	ks.mu.RUnlock()
+
+	if kid == "" {
+		return "", nil, fmt.Errorf("keystores: no current key")
+	}
+	key, err := ks.GetByID(kid)
+	return kid, key, err
+}
+
+func (ks *LocalKeyStore) GetByID(kid string) (*rsa.PrivateKey, error) {
+	if err := validateKeyID(kid); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(ks.keyPath(kid))
+	if err != nil {
+		return nil, fmt.Errorf("keystores: failed to read key %q: %w", kid, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("keystores: key %q is not valid PEM", kid)
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("keystores: failed to parse key %q: %w", kid, err)
+	}
+	return key, nil
+}
+
+func (ks *LocalKeyStore) Rotate() (string, *rsa.PublicKey, error) {
+	priv, err := generateRSAKey()
+	if err != nil {
+		return "", nil, fmt.Errorf("keystores: failed to generate rotated key: %w", err)
+	}
+	kid, err := newKeyID()
+	if err != nil {
+		return "", nil, fmt.Errorf("keystores: failed to generate key id: %w", err)
+	}
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}
+	if err := os.WriteFile(ks.keyPath(kid), pem.EncodeToMemory(block), 0o600); err != nil {
+		return "", nil, fmt.Errorf("keystores: failed to persist rotated key: %w", err)
+	}
+	if err := os.WriteFile(ks.currentPath(), []byte(kid), 0o600); err != nil {
+		return "", nil, fmt.Errorf("keystores: failed to record current key id: %w", err)
+	}
+
+	ks.mu.Lock()
+	ks.current = kid
+	ks.mu.Unlock()
+
+	return kid, &priv.PublicKey, nil
+}
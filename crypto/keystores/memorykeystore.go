@@ -0,0 +1,70 @@
+package keystores
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"sync"
+)
+
+// MemoryKeyStore keeps every generated key in memory and never persists
+// anything; it's the original behavior of this package's predecessor
+// (a single in-memory RSA keypair), extended to support rotation.
+type MemoryKeyStore struct {
+	mu         sync.RWMutex
+	keys       map[string]*rsa.PrivateKey
+	currentKid string
+
+	// PublicKey mirrors the current key's public half, kept so callers that
+	// only need to seal envelopes don't have to look up a kid first.
+	PublicKey *rsa.PublicKey
+}
+
+// NewMemoryKeyStore creates a MemoryKeyStore with one freshly-generated
+// RSA-2048 key as the current key.
+func NewMemoryKeyStore() (*MemoryKeyStore, error) {
+	ms := &MemoryKeyStore{keys: make(map[string]*rsa.PrivateKey)}
+	if _, _, err := ms.Rotate(); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (m *MemoryKeyStore) CurrentKey() (string, *rsa.PrivateKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.currentKid == "" {
+		return "", nil, fmt.Errorf("keystores: no current key")
+	}
+	return m.currentKid, m.keys[m.currentKid], nil
+}
+
+func (m *MemoryKeyStore) GetByID(kid string) (*rsa.PrivateKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("keystores: no key with id %q", kid)
+	}
+	return key, nil
+}
+
+func (m *MemoryKeyStore) Rotate() (string, *rsa.PublicKey, error) {
+	priv, err := generateRSAKey()
+	if err != nil {
+		return "", nil, fmt.Errorf("keystores: failed to generate rotated key: %w", err)
+	}
+	kid, err := newKeyID()
+	if err != nil {
+		return "", nil, fmt.Errorf("keystores: failed to generate key id: %w", err)
+	}
+
+	m.mu.Lock()
+	m.keys[kid] = priv
+	m.currentKid = kid
+	m.PublicKey = &priv.PublicKey
+	m.mu.Unlock()
+
+	return kid, &priv.PublicKey, nil
+}
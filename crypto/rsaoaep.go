@@ -0,0 +1,147 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudx-io/openauction/core/audit"
+)
+
+// RSAOAEPAlgorithmName is the algorithm tag for the original hybrid
+// RSA-OAEP-SHA256 + AES-256-GCM scheme.
+const RSAOAEPAlgorithmName = "rsa-oaep-sha256+aes-256-gcm/v1"
+
+type rsaOAEPEnvelope struct {
+	AESKeyEncrypted  string `json:"aes_key_encrypted"`
+	EncryptedPayload string `json:"encrypted_payload"`
+	Nonce            string `json:"nonce"`
+}
+
+// RSAOAEPAlgorithm implements Algorithm and EncryptingAlgorithm for the
+// hybrid RSA-OAEP + AES-256-GCM scheme.
+type RSAOAEPAlgorithm struct{}
+
+func (RSAOAEPAlgorithm) Name() string { return RSAOAEPAlgorithmName }
+
+func (RSAOAEPAlgorithm) Decrypt(envelopeJSON []byte, key any, log *audit.Log) ([]byte, error) {
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("crypto: %s needs an *rsa.PrivateKey, got %T", RSAOAEPAlgorithmName, key)
+	}
+
+	var env rsaOAEPEnvelope
+	if err := json.Unmarshal(envelopeJSON, &env); err != nil {
+		return nil, fmt.Errorf("crypto: failed to parse %s envelope: %w", RSAOAEPAlgorithmName, err)
+	}
+
+	return DecryptRSAOAEPHybrid(env.AESKeyEncrypted, env.EncryptedPayload, env.Nonce, privateKey, log)
+}
+
+func (RSAOAEPAlgorithm) Encrypt(plaintext []byte, key any) ([]byte, error) {
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("crypto: %s needs an *rsa.PublicKey, got %T", RSAOAEPAlgorithmName, key)
+	}
+
+	aesKey := make([]byte, 32)
+	if _, err := rand.Read(aesKey); err != nil {
+		return nil, fmt.Errorf("crypto: failed to generate AES key: %w", err)
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to create AES cipher: %w", err)
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to create GCM: %w", err)
+	}
+
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("crypto: failed to generate nonce: %w", err)
+	}
+	ciphertext := aesgcm.Seal(nil, nonce, plaintext, nil)
+
+	encryptedAESKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, aesKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to encrypt AES key: %w", err)
+	}
+
+	return json.Marshal(rsaOAEPEnvelope{
+		AESKeyEncrypted:  base64.StdEncoding.EncodeToString(encryptedAESKey),
+		EncryptedPayload: base64.StdEncoding.EncodeToString(ciphertext),
+		Nonce:            base64.StdEncoding.EncodeToString(nonce),
+	})
+}
+
+// DecryptRSAOAEPHybrid decrypts data encrypted with hybrid RSA-OAEP +
+// AES-256-GCM encryption: an RSA-OAEP-wrapped AES-256 key, then an
+// AES-256-GCM sealed payload. log, when non-nil, receives a decrypt_success
+// or decrypt_failure entry for the call; it's the caller's round
+// transcript, not a package-level global, so concurrently running rounds
+// never share one.
+func DecryptRSAOAEPHybrid(encryptedAESKey, encryptedPayload, nonceB64 string, privateKey *rsa.PrivateKey, log *audit.Log) ([]byte, error) {
+	encryptedAESKeyBytes, err := base64.StdEncoding.DecodeString(encryptedAESKey)
+	if err != nil {
+		return nil, recordDecryptFailure(fmt.Errorf("failed to decode encrypted AES key: %w", err), log)
+	}
+
+	encryptedPayloadBytes, err := base64.StdEncoding.DecodeString(encryptedPayload)
+	if err != nil {
+		return nil, recordDecryptFailure(fmt.Errorf("failed to decode encrypted payload: %w", err), log)
+	}
+
+	nonceBytes, err := base64.StdEncoding.DecodeString(nonceB64)
+	if err != nil {
+		return nil, recordDecryptFailure(fmt.Errorf("failed to decode nonce: %w", err), log)
+	}
+
+	aesKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privateKey, encryptedAESKeyBytes, nil)
+	if err != nil {
+		return nil, recordDecryptFailure(fmt.Errorf("failed to decrypt AES key: %w", err), log)
+	}
+	if len(aesKey) != 32 {
+		return nil, recordDecryptFailure(fmt.Errorf("invalid AES key length: expected 32 bytes, got %d", len(aesKey)), log)
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, recordDecryptFailure(fmt.Errorf("failed to create AES cipher: %w", err), log)
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, recordDecryptFailure(fmt.Errorf("failed to create GCM: %w", err), log)
+	}
+
+	if len(nonceBytes) != aesgcm.NonceSize() {
+		return nil, recordDecryptFailure(fmt.Errorf("invalid nonce length: expected %d bytes, got %d", aesgcm.NonceSize(), len(nonceBytes)), log)
+	}
+
+	plaintext, err := aesgcm.Open(nil, nonceBytes, encryptedPayloadBytes, nil)
+	if err != nil {
+		return nil, recordDecryptFailure(fmt.Errorf("failed to decrypt payload: %w", err), log)
+	}
+
+	if log != nil {
+		log.Append(audit.KindDecryptSuccess, []byte(nonceB64))
+	}
+	return plaintext, nil
+}
+
+// recordDecryptFailure writes a decrypt_failure entry to log if one has
+// been provided, keyed off the error text rather than any decrypted
+// material, and returns err unchanged so callers can keep using the
+// `return nil, recordDecryptFailure(err, log)` one-liner.
+func recordDecryptFailure(err error, log *audit.Log) error {
+	if log != nil {
+		log.Append(audit.KindDecryptFailure, []byte(err.Error()))
+	}
+	return err
+}
@@ -0,0 +1,37 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/peterldowns/testy/check"
+
+	"github.com/cloudx-io/openauction/core/audit"
+)
+
+func TestDecryptRSAOAEPHybrid_RecordsAuditSuccess(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	check.NoError(t, err)
+
+	e := NewDefaultEngine()
+	envelope, err := e.Encrypt(RSAOAEPAlgorithmName, []byte(`{"price":2.5}`), &privateKey.PublicKey)
+	check.NoError(t, err)
+
+	log := audit.NewLog("round-1", nil)
+
+	_, err = e.Decrypt(RSAOAEPAlgorithmName, envelope, privateKey, log)
+	check.NoError(t, err)
+	check.Equal(t, 1, log.Len())
+}
+
+func TestDecryptRSAOAEPHybrid_RecordsAuditFailure(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	check.NoError(t, err)
+
+	log := audit.NewLog("round-1", nil)
+
+	_, err = DecryptRSAOAEPHybrid("not-base64!", "not-base64!", "not-base64!", privateKey, log)
+	check.NotNil(t, err)
+	check.Equal(t, 1, log.Len())
+}
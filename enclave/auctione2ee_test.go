@@ -7,6 +7,7 @@ import (
 	"github.com/peterldowns/testy/assert"
 
 	"github.com/cloudx-io/openauction/core"
+	pkgcrypto "github.com/cloudx-io/openauction/crypto"
 	"github.com/cloudx-io/openauction/enclaveapi"
 )
 
@@ -18,7 +19,7 @@ func TestDecryptBids_NoEncryptedData(t *testing.T) {
 		{CoreBid: core.CoreBid{ID: "bid2", Bidder: "bidder2", Price: 3.00}},
 	}
 
-	decryptedData, _, errors := decryptAllBids(encBids, km)
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
 	assert.Equal(t, 0, len(errors))
 
 	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
@@ -49,7 +50,7 @@ func TestDecryptBids_WithEncryptedData(t *testing.T) {
 		},
 	}
 
-	decryptedData, _, errors := decryptAllBids(encBids, km)
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
 	assert.Equal(t, 0, len(errors))
 
 	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
@@ -84,7 +85,7 @@ func TestDecryptBids_MixedEncryptedUnencrypted(t *testing.T) {
 		{CoreBid: core.CoreBid{ID: "bid3", Bidder: "bidder3", Price: 3.75}},
 	}
 
-	decryptedData, _, errors := decryptAllBids(encBids, km)
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
 	assert.Equal(t, 0, len(errors))
 
 	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
@@ -111,7 +112,7 @@ func TestDecryptBids_InvalidEncryptedData(t *testing.T) {
 		},
 	}
 
-	decryptedData, _, errors := decryptAllBids(encBids, km)
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
 	assert.Equal(t, 1, len(errors))
 
 	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
@@ -141,7 +142,7 @@ func TestDecryptBids_InvalidPrice(t *testing.T) {
 		},
 	}
 
-	decryptedData, _, errors := decryptAllBids(encBids, km)
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
 	assert.Equal(t, 0, len(errors)) // Decryption succeeds
 
 	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
@@ -153,7 +154,7 @@ func TestDecryptBids_NilKeyManager(t *testing.T) {
 		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1", Price: 2.50}},
 	}
 
-	decryptedData, excludedBids, errors := decryptAllBids(encBids, nil)
+	decryptedData, excludedBids, errors := decryptAllBids(encBids, nil, nil)
 	assert.Equal(t, 0, len(errors))
 	assert.Equal(t, 0, len(excludedBids))
 
@@ -185,7 +186,7 @@ func TestDecryptBids_WrongKey(t *testing.T) {
 		},
 	}
 
-	decryptedData, excludedBids, errors := decryptAllBids(encBids, km2)
+	decryptedData, excludedBids, errors := decryptAllBids(encBids, km2, nil)
 	assert.Equal(t, 1, len(errors))
 	assert.Equal(t, 1, len(excludedBids)) // Should be excluded
 	assert.Equal(t, "bid1", excludedBids[0].BidID)
@@ -220,7 +221,7 @@ func TestDecryptBids_BothEncryptedAndUnencryptedPrice(t *testing.T) {
 		},
 	}
 
-	decryptedData, excludedBids, errors := decryptAllBids(encBids, km)
+	decryptedData, excludedBids, errors := decryptAllBids(encBids, km, nil)
 	// Should successfully decrypt
 	assert.Equal(t, 0, len(errors))
 	assert.Equal(t, 0, len(excludedBids))
@@ -233,3 +234,203 @@ func TestDecryptBids_BothEncryptedAndUnencryptedPrice(t *testing.T) {
 	assert.Equal(t, "bidder1", bid.Bidder)
 	assert.Equal(t, 7.25, bid.Price) // Should use encrypted price, not CoreBid.Price
 }
+
+// The following mirror the hybrid RSA-OAEP cases above, but for bidders who
+// seal their price with ECIES instead.
+
+func TestDecryptBids_WithEncryptedData_ECIES(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	payload := map[string]any{
+		"price": 5.75,
+	}
+	plaintextBytes, _ := json.Marshal(payload)
+	result, _ := EncryptECIES(plaintextBytes, km.ECIESPublicKey)
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{
+			CoreBid: core.CoreBid{
+				ID:     "bid1",
+				Bidder: "bidder1",
+			},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				Algorithm:          pkgcrypto.ECIESAlgorithmName,
+				EphemeralPublicKey: result.EphemeralPublicKey,
+				EncryptedPayload:   result.EncryptedPayload,
+				Nonce:              result.Nonce,
+			},
+		},
+	}
+
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
+	assert.Equal(t, 0, len(errors))
+
+	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
+	assert.Equal(t, 1, len(finalBids))
+	assert.Equal(t, 5.75, finalBids[0].Price)
+}
+
+func TestDecryptBids_MixedEncryptedUnencrypted_ECIES(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	payload := map[string]any{
+		"price": 4.25,
+	}
+	plaintextBytes, _ := json.Marshal(payload)
+	result, _ := EncryptECIES(plaintextBytes, km.ECIESPublicKey)
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1", Price: 2.50}},
+		{
+			CoreBid: core.CoreBid{
+				ID:     "bid2",
+				Bidder: "bidder2",
+			},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				Algorithm:          pkgcrypto.ECIESAlgorithmName,
+				EphemeralPublicKey: result.EphemeralPublicKey,
+				EncryptedPayload:   result.EncryptedPayload,
+				Nonce:              result.Nonce,
+			},
+		},
+		{CoreBid: core.CoreBid{ID: "bid3", Bidder: "bidder3", Price: 3.75}},
+	}
+
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
+	assert.Equal(t, 0, len(errors))
+
+	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
+	assert.Equal(t, 3, len(finalBids))
+	assert.Equal(t, 2.50, finalBids[0].Price)
+	assert.Equal(t, 4.25, finalBids[1].Price)
+	assert.Equal(t, 3.75, finalBids[2].Price)
+}
+
+func TestDecryptBids_InvalidEncryptedData_ECIES(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{
+			CoreBid: core.CoreBid{
+				ID:     "bid1",
+				Bidder: "bidder1",
+			},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				Algorithm:          pkgcrypto.ECIESAlgorithmName,
+				EphemeralPublicKey: "invalid-base64",
+				EncryptedPayload:   "invalid-base64",
+				Nonce:              "invalid-base64",
+			},
+		},
+	}
+
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
+	assert.Equal(t, 1, len(errors))
+
+	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
+	assert.Equal(t, 0, len(finalBids)) // Excluded
+}
+
+func TestDecryptBids_InvalidPrice_ECIES(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	payload := map[string]any{
+		"price": -1.50,
+	}
+	plaintextBytes, _ := json.Marshal(payload)
+	result, _ := EncryptECIES(plaintextBytes, km.ECIESPublicKey)
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{
+			CoreBid: core.CoreBid{
+				ID:     "bid1",
+				Bidder: "bidder1",
+			},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				Algorithm:          pkgcrypto.ECIESAlgorithmName,
+				EphemeralPublicKey: result.EphemeralPublicKey,
+				EncryptedPayload:   result.EncryptedPayload,
+				Nonce:              result.Nonce,
+			},
+		},
+	}
+
+	decryptedData, _, errors := decryptAllBids(encBids, km, nil)
+	assert.Equal(t, 0, len(errors)) // Decryption succeeds
+
+	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
+	assert.Equal(t, 0, len(finalBids)) // Excluded due to invalid price in filtering stage
+}
+
+func TestDecryptBids_WrongKey_ECIES(t *testing.T) {
+	km1, _ := NewKeyManager()
+	km2, _ := NewKeyManager()
+
+	payload := map[string]any{
+		"price": 2.50,
+	}
+	plaintextBytes, _ := json.Marshal(payload)
+	result, _ := EncryptECIES(plaintextBytes, km1.ECIESPublicKey)
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{
+			CoreBid: core.CoreBid{
+				ID:     "bid1",
+				Bidder: "bidder1",
+			},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				Algorithm:          pkgcrypto.ECIESAlgorithmName,
+				EphemeralPublicKey: result.EphemeralPublicKey,
+				EncryptedPayload:   result.EncryptedPayload,
+				Nonce:              result.Nonce,
+			},
+		},
+	}
+
+	decryptedData, excludedBids, errors := decryptAllBids(encBids, km2, nil)
+	assert.Equal(t, 1, len(errors))
+	assert.Equal(t, 1, len(excludedBids)) // Should be excluded
+	assert.Equal(t, "bid1", excludedBids[0].BidID)
+	assert.Equal(t, "bidder1", excludedBids[0].Bidder)
+
+	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
+	assert.Equal(t, 0, len(finalBids)) // Should fail
+}
+
+func TestDecryptBids_BothEncryptedAndUnencryptedPrice_ECIES(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	payload := map[string]any{
+		"price": 7.25, // This should take precedence
+	}
+	plaintextBytes, _ := json.Marshal(payload)
+	result, _ := EncryptECIES(plaintextBytes, km.ECIESPublicKey)
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{
+			CoreBid: core.CoreBid{
+				ID:     "bid1",
+				Bidder: "bidder1",
+				Price:  2.50, // This should be ignored in favor of encrypted price
+			},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				Algorithm:          pkgcrypto.ECIESAlgorithmName,
+				EphemeralPublicKey: result.EphemeralPublicKey,
+				EncryptedPayload:   result.EncryptedPayload,
+				Nonce:              result.Nonce,
+			},
+		},
+	}
+
+	decryptedData, excludedBids, errors := decryptAllBids(encBids, km, nil)
+	assert.Equal(t, 0, len(errors))
+	assert.Equal(t, 0, len(excludedBids))
+
+	finalBids, _ := filterBidsByConsumedTokens(decryptedData, make(map[string]bool))
+	assert.Equal(t, 1, len(finalBids))
+
+	bid := finalBids[0]
+	assert.Equal(t, "bid1", bid.ID)
+	assert.Equal(t, "bidder1", bid.Bidder)
+	assert.Equal(t, 7.25, bid.Price) // Should use encrypted price, not CoreBid.Price
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"github.com/cloudx-io/openauction/core"
+	"github.com/cloudx-io/openauction/core/audit"
+	"github.com/cloudx-io/openauction/core/bidauth"
+	"github.com/cloudx-io/openauction/enclaveapi"
+)
+
+// RunAuctionRound is the seam between bid ingress and
+// core.RankCoreBidsWithOptions: it gates incoming bids through rl and
+// allowlist before a single RSA-OAEP/ECIES decrypt runs, then ties decrypt
+// errors and sealed payloads, which core never sees, into the same envelope
+// ResponseOptions controls. rl and allowlist are both optional: either one
+// being nil disables that gate and passes every bid through to the next
+// stage unchanged. log is this round's transcript (nil disables auditing);
+// callers construct one *audit.Log per round, since a shared global
+// couldn't distinguish concurrently running rounds.
+func RunAuctionRound(encBids []enclaveapi.EncryptedCoreBid, km *KeyManager, consumedTokens map[string]bool, opts core.ResponseOptions, rl *RateLimiter, allowlist *bidauth.Allowlist, log *audit.Log) *core.AuctionResultEnvelope {
+	rateLimited, throttled := rateLimitBids(rl, encBids, nil)
+	authorized, authExcluded := verifyBidAuth(allowlist, rateLimited)
+
+	decrypted, decryptExcluded, errs := decryptAllBids(authorized, km, log)
+	kept, filterExcluded := filterBidsByConsumedTokens(decrypted, consumedTokens)
+
+	envelope := core.RankCoreBidsWithOptions(kept, opts, log)
+
+	if opts.IncludeDecryptErrors {
+		for _, err := range errs {
+			envelope.DecryptErrors = append(envelope.DecryptErrors, err.Error())
+		}
+	}
+
+	if opts.IncludeExcludedBids {
+		for _, excluded := range throttled {
+			envelope.ExcludedBids = append(envelope.ExcludedBids, excludedBidToCoreBid(excluded, opts))
+		}
+		for _, excluded := range authExcluded {
+			envelope.ExcludedBids = append(envelope.ExcludedBids, excludedBidToCoreBid(excluded, opts))
+		}
+		for _, excluded := range decryptExcluded {
+			envelope.ExcludedBids = append(envelope.ExcludedBids, excludedBidToCoreBid(excluded, opts))
+		}
+		for _, excluded := range filterExcluded {
+			envelope.ExcludedBids = append(envelope.ExcludedBids, excludedBidToCoreBid(excluded, opts))
+		}
+	}
+
+	if opts.ReturnSealedPayloads {
+		for _, encBid := range encBids {
+			if encBid.EncryptedPrice != nil {
+				envelope.SealedPayloads = append(envelope.SealedPayloads, *encBid.EncryptedPrice)
+			}
+		}
+	}
+
+	return envelope
+}
+
+// excludedBidToCoreBid turns a pre-ranking exclusion (rate limit, failed
+// signature check, decrypt failure, negative price, or spent token) into
+// the same CoreBid shape buildEnvelope uses for
+// reserve-excluded bids, applying the same bidder-ID redaction so a
+// RedactBidderIDs caller doesn't leak identities core never saw.
+func excludedBidToCoreBid(excluded ExcludedBid, opts core.ResponseOptions) core.CoreBid {
+	bidder := excluded.Bidder
+	if opts.RedactBidderIDs {
+		bidder = core.RedactBidderID(opts.RedactionSalt, bidder)
+	}
+	return core.CoreBid{ID: excluded.BidID, Bidder: bidder}
+}
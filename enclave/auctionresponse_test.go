@@ -0,0 +1,169 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/peterldowns/testy/assert"
+
+	"github.com/cloudx-io/openauction/core"
+	"github.com/cloudx-io/openauction/core/audit"
+	"github.com/cloudx-io/openauction/core/bidauth"
+	"github.com/cloudx-io/openauction/enclaveapi"
+)
+
+func TestRunAuctionRound_DefaultOmitsExtras(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1", Price: 2.50}},
+		{CoreBid: core.CoreBid{ID: "bid2", Bidder: "bidder2", Price: 3.00}},
+	}
+
+	envelope := RunAuctionRound(encBids, km, make(map[string]bool), core.ResponseOptions{}, nil, nil, nil)
+
+	assert.Equal(t, core.AuctionResultEnvelopeVersion, envelope.Version)
+	assert.Equal(t, 0, len(envelope.ExcludedBids))
+	assert.Equal(t, 0, len(envelope.DecryptErrors))
+	assert.Equal(t, 0, len(envelope.SealedPayloads))
+}
+
+func TestRunAuctionRound_IncludeDecryptErrors(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{
+			CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1"},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				AESKeyEncrypted:  "invalid-base64",
+				EncryptedPayload: "invalid-base64",
+				Nonce:            "invalid-base64",
+			},
+		},
+	}
+
+	envelope := RunAuctionRound(encBids, km, make(map[string]bool), core.ResponseOptions{IncludeDecryptErrors: true}, nil, nil, nil)
+	assert.Equal(t, 1, len(envelope.DecryptErrors))
+}
+
+func TestRunAuctionRound_IncludeExcludedBidsCoversDecryptAndRanking(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1", Price: 5.00}},
+		{
+			CoreBid: core.CoreBid{ID: "bid2", Bidder: "bidder2"},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				AESKeyEncrypted:  "invalid-base64",
+				EncryptedPayload: "invalid-base64",
+				Nonce:            "invalid-base64",
+			},
+		},
+	}
+
+	envelope := RunAuctionRound(encBids, km, make(map[string]bool), core.ResponseOptions{IncludeExcludedBids: true}, nil, nil, nil)
+	assert.Equal(t, 1, len(envelope.ExcludedBids))
+	assert.Equal(t, "bidder2", envelope.ExcludedBids[0].Bidder)
+}
+
+func TestRunAuctionRound_ReturnSealedPayloads(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{
+			CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1"},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				AESKeyEncrypted:  "abc",
+				EncryptedPayload: "def",
+				Nonce:            "ghi",
+			},
+		},
+	}
+
+	envelope := RunAuctionRound(encBids, km, make(map[string]bool), core.ResponseOptions{ReturnSealedPayloads: true}, nil, nil, nil)
+	assert.Equal(t, 1, len(envelope.SealedPayloads))
+	assert.Equal(t, "abc", envelope.SealedPayloads[0].AESKeyEncrypted)
+}
+
+func TestRunAuctionRound_RedactBidderIDsAppliesToExcludedBids(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{
+			CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1"},
+			EncryptedPrice: &core.EncryptedBidPrice{
+				AESKeyEncrypted:  "invalid-base64",
+				EncryptedPayload: "invalid-base64",
+				Nonce:            "invalid-base64",
+			},
+		},
+	}
+
+	envelope := RunAuctionRound(encBids, km, make(map[string]bool), core.ResponseOptions{
+		IncludeExcludedBids: true,
+		RedactBidderIDs:     true,
+		RedactionSalt:       "round-7-salt",
+	}, nil, nil, nil)
+
+	want := core.RedactBidderID("round-7-salt", "bidder1")
+	assert.Equal(t, want, envelope.ExcludedBids[0].Bidder)
+}
+
+func TestRunAuctionRound_RateLimitDropsBidBeforeDecrypt(t *testing.T) {
+	withVirtualClock(t)
+	km, _ := NewKeyManager()
+	rl := NewRateLimiter(RateLimitConfig{RatePerSecond: 1, Burst: 1})
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1", Price: 2.50}},
+		{CoreBid: core.CoreBid{ID: "bid2", Bidder: "bidder1", Price: 3.00}},
+	}
+
+	envelope := RunAuctionRound(encBids, km, make(map[string]bool), core.ResponseOptions{IncludeExcludedBids: true}, rl, nil, nil)
+
+	assert.Equal(t, 1, len(envelope.ExcludedBids))
+	assert.Equal(t, "bidder1", envelope.ExcludedBids[0].Bidder)
+	assert.Equal(t, uint64(1), rl.Metrics.Throttled)
+}
+
+func TestRunAuctionRound_AllowlistRejectsUnregisteredBidderBeforeDecrypt(t *testing.T) {
+	km, _ := NewKeyManager()
+	allowlist := bidauth.NewAllowlist() // bidder1 never registered
+
+	_, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	env := &core.EncryptedBidPrice{
+		AESKeyEncrypted:  "abc",
+		EncryptedPayload: "def",
+		Nonce:            "ghi",
+		AuctionID:        "auction-1",
+		Timestamp:        time.Now(),
+		BidderNonce:      1,
+	}
+	env.Signature = bidauth.Sign(priv, env)
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1"}, EncryptedPrice: env},
+	}
+
+	envelope := RunAuctionRound(encBids, km, make(map[string]bool), core.ResponseOptions{IncludeExcludedBids: true}, nil, allowlist, nil)
+
+	assert.Equal(t, 1, len(envelope.ExcludedBids))
+	assert.Equal(t, "bidder1", envelope.ExcludedBids[0].Bidder)
+}
+
+func TestRunAuctionRound_RecordsRankingResultOnProvidedLog(t *testing.T) {
+	km, _ := NewKeyManager()
+
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1", Price: 2.50}},
+		{CoreBid: core.CoreBid{ID: "bid2", Bidder: "bidder2", Price: 3.00}},
+	}
+
+	log := audit.NewLog("round-1", nil)
+	RunAuctionRound(encBids, km, make(map[string]bool), core.ResponseOptions{}, nil, nil, log)
+
+	assert.Equal(t, 1, log.Len())
+}
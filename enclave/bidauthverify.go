@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/cloudx-io/openauction/core/bidauth"
+	"github.com/cloudx-io/openauction/enclaveapi"
+)
+
+// verifyBidAuth checks every bid carrying a sealed price against allowlist
+// before decryptAllBids ever runs, so a forged envelope or a bidder who
+// isn't registered for this round is rejected before it costs a single
+// RSA-OAEP/ECIES decrypt cycle. allowlist == nil disables the check
+// entirely, passing every bid through unchanged. A bid with no
+// EncryptedPrice has nothing for Allowlist.Verify to check a signature
+// over, so it passes through regardless.
+func verifyBidAuth(allowlist *bidauth.Allowlist, encBids []enclaveapi.EncryptedCoreBid) (allowed []enclaveapi.EncryptedCoreBid, excluded []ExcludedBid) {
+	if allowlist == nil {
+		return encBids, nil
+	}
+
+	for _, bid := range encBids {
+		if bid.EncryptedPrice == nil {
+			allowed = append(allowed, bid)
+			continue
+		}
+
+		if err := allowlist.Verify(bid.Bidder, bid.EncryptedPrice); err != nil {
+			excluded = append(excluded, ExcludedBid{
+				BidID:  bid.ID,
+				Bidder: bid.Bidder,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		allowed = append(allowed, bid)
+	}
+	return allowed, excluded
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudx-io/openauction/core"
+	"github.com/cloudx-io/openauction/core/audit"
+	pkgcrypto "github.com/cloudx-io/openauction/crypto"
+	"github.com/cloudx-io/openauction/enclaveapi"
+)
+
+// DecryptEngine is the crypto.Engine decryptOneBid dispatches to, keyed by
+// EncryptedBidPrice.Algorithm. It's a package variable rather than a
+// parameter threaded through decryptAllBids so algorithms beyond the
+// defaults (RSA-OAEP, ECIES, legacy AES-CFB) can be registered by the
+// server at startup without changing callers.
+var DecryptEngine = pkgcrypto.NewDefaultEngine()
+
+// DecryptedBid is a CoreBid whose price has been recovered from an
+// EncryptedCoreBid, along with any auction token that was sealed alongside
+// it. AuctionToken is empty for bids that never had one.
+type DecryptedBid struct {
+	core.CoreBid
+	AuctionToken string
+}
+
+// ExcludedBid records a bid dropped somewhere in the decrypt/filter
+// pipeline, and why, so callers can explain a rejection to its bidder
+// without re-deriving the reason.
+type ExcludedBid struct {
+	BidID  string
+	Bidder string
+	Reason string
+}
+
+// decryptAllBids decrypts the price on every bid that has one sealed,
+// leaving bids that were submitted in the clear untouched. It never fails
+// the whole batch: a bid that can't be decrypted is reported in excluded
+// and errs but every other bid is still processed. log is the caller's
+// round transcript (nil disables auditing); see Engine.Decrypt.
+func decryptAllBids(encBids []enclaveapi.EncryptedCoreBid, km *KeyManager, log *audit.Log) (decrypted []DecryptedBid, excluded []ExcludedBid, errs []error) {
+	for _, encBid := range encBids {
+		bid, err := decryptOneBid(encBid, km, log)
+		if err != nil {
+			errs = append(errs, err)
+			excluded = append(excluded, ExcludedBid{
+				BidID:  encBid.ID,
+				Bidder: encBid.Bidder,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		decrypted = append(decrypted, bid)
+	}
+	return decrypted, excluded, errs
+}
+
+// decryptOneBid recovers a single bid's price. Bids with no EncryptedPrice
+// pass through unchanged; everything else is decrypted with whichever
+// algorithm and key the envelope names, defaulting to the original
+// RSA-OAEP hybrid scheme and the key manager's current key for envelopes
+// sealed before those fields existed.
+func decryptOneBid(encBid enclaveapi.EncryptedCoreBid, km *KeyManager, log *audit.Log) (DecryptedBid, error) {
+	if encBid.EncryptedPrice == nil {
+		return DecryptedBid{CoreBid: encBid.CoreBid}, nil
+	}
+	if km == nil {
+		return DecryptedBid{}, fmt.Errorf("enclave: bid %q has a sealed price but no key manager is configured", encBid.ID)
+	}
+
+	algorithm := encBid.EncryptedPrice.Algorithm
+	if algorithm == "" {
+		algorithm = pkgcrypto.RSAOAEPAlgorithmName
+	}
+
+	var key any
+	var err error
+	switch algorithm {
+	case pkgcrypto.ECIESAlgorithmName:
+		key = km.ECIESPrivateKey
+	default:
+		if encBid.EncryptedPrice.KeyID != "" {
+			key, err = km.GetByID(encBid.EncryptedPrice.KeyID)
+		} else {
+			_, key, err = km.CurrentKey()
+		}
+	}
+	if err != nil {
+		return DecryptedBid{}, fmt.Errorf("enclave: no key available to decrypt bid %q: %w", encBid.ID, err)
+	}
+
+	envelopeJSON, err := json.Marshal(encBid.EncryptedPrice)
+	if err != nil {
+		return DecryptedBid{}, fmt.Errorf("enclave: failed to marshal envelope for bid %q: %w", encBid.ID, err)
+	}
+
+	plaintext, err := DecryptEngine.Decrypt(algorithm, envelopeJSON, key, log)
+	if err != nil {
+		return DecryptedBid{}, fmt.Errorf("enclave: failed to decrypt bid %q: %w", encBid.ID, err)
+	}
+
+	var payload core.DecryptedBidPayload
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		return DecryptedBid{}, fmt.Errorf("enclave: failed to parse decrypted payload for bid %q: %w", encBid.ID, err)
+	}
+
+	bid := encBid.CoreBid
+	bid.Price = payload.Price
+	return DecryptedBid{CoreBid: bid, AuctionToken: payload.AuctionToken}, nil
+}
+
+// filterBidsByConsumedTokens drops decrypted bids with a negative price and
+// bids whose auction token has already been spent, recording why each was
+// dropped. Bids with no token are always kept (token checks are opt-in).
+func filterBidsByConsumedTokens(bids []DecryptedBid, consumedTokens map[string]bool) ([]core.CoreBid, []ExcludedBid) {
+	var kept []core.CoreBid
+	var excluded []ExcludedBid
+
+	for _, bid := range bids {
+		if bid.Price < 0 {
+			excluded = append(excluded, ExcludedBid{
+				BidID:  bid.ID,
+				Bidder: bid.Bidder,
+				Reason: fmt.Sprintf("invalid price: %v", bid.Price),
+			})
+			continue
+		}
+		if bid.AuctionToken != "" && consumedTokens[bid.AuctionToken] {
+			excluded = append(excluded, ExcludedBid{
+				BidID:  bid.ID,
+				Bidder: bid.Bidder,
+				Reason: "auction token already consumed",
+			})
+			continue
+		}
+		kept = append(kept, bid.CoreBid)
+	}
+	return kept, excluded
+}
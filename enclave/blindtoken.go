@@ -0,0 +1,289 @@
+package main
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// blindCurve is the elliptic curve used for the Privacy Pass style blind
+// token scheme. P-256 is used rather than Ristretto255 since it's already
+// available via crypto/elliptic without pulling in an extra dependency.
+var blindCurve = elliptic.P256()
+
+// ECPoint is a point on blindCurve.
+type ECPoint struct {
+	X, Y *big.Int
+}
+
+// DLEQProof is a non-interactive Chaum-Pedersen proof that the same scalar x
+// satisfies both Y = xG and Z = xB, built over a Fiat-Shamir transcript of
+// (G, Y, B, Z). It lets a bidder confirm the issuer answered with its real
+// signing key without ever learning x.
+type DLEQProof struct {
+	C *big.Int
+	S *big.Int
+}
+
+// BlindIssuer holds the auction server's long-lived signing keypair for
+// Privacy Pass style anonymous single-use tokens: a scalar x and the public
+// point Y = xG. Bidders only ever see Y and the blinded values exchanged
+// during issuance, never x.
+type BlindIssuer struct {
+	x *big.Int
+	Y ECPoint
+}
+
+// NewBlindIssuer generates a fresh signing keypair for blind token issuance.
+func NewBlindIssuer() (*BlindIssuer, error) {
+	x, Yx, Yy, err := elliptic.GenerateKey(blindCurve, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate blind issuer keypair: %w", err)
+	}
+	return &BlindIssuer{
+		x: new(big.Int).SetBytes(x),
+		Y: ECPoint{X: Yx, Y: Yy},
+	}, nil
+}
+
+// BlindTokenManager issues and redeems Privacy Pass style tokens on top of
+// the TokenManager's spent-token set (kept apart from its UUID tokens), so a blind-issued
+// token gets the same single-use replay protection without the issuance
+// request ever being linkable to the bid that later redeems it.
+type BlindTokenManager struct {
+	issuer *BlindIssuer
+	tm     *TokenManager
+}
+
+// NewBlindTokenManager creates a BlindTokenManager backed by tm's spent-token
+// set. The plain UUID flow on tm keeps working unchanged; this is purely
+// additive.
+func NewBlindTokenManager(tm *TokenManager) (*BlindTokenManager, error) {
+	issuer, err := NewBlindIssuer()
+	if err != nil {
+		return nil, err
+	}
+	return &BlindTokenManager{issuer: issuer, tm: tm}, nil
+}
+
+// PublicKey returns the issuer's public point Y, which bidders need to
+// verify DLEQ proofs returned by IssueBlindToken.
+func (btm *BlindTokenManager) PublicKey() ECPoint {
+	return btm.issuer.Y
+}
+
+// IssueBlindToken answers a blind-issuance request. B is the bidder's
+// blinded point rT, submitted without revealing the underlying token seed t.
+// It returns Z = xB and a DLEQ proof that the same x was used as in Y = xG.
+func (btm *BlindTokenManager) IssueBlindToken(B ECPoint) (ECPoint, DLEQProof, error) {
+	return btm.issuer.IssueBlindToken(B)
+}
+
+// VerifyAndConsumeBlindToken is called inside the TEE at redemption time.
+// The bidder submits the token seed t in the clear along with a MAC of the
+// bid request keyed by W = xT (which only the bidder could have derived via
+// unblinding, and which the issuer can independently recompute from t and
+// its own x). On success the seed is atomically marked spent so the same
+// token can never be redeemed twice.
+func (btm *BlindTokenManager) VerifyAndConsumeBlindToken(seed, mac, request []byte) (bool, error) {
+	T := HashToCurvePoint(seed)
+	W := scalarMult(T, btm.issuer.x)
+
+	expectedMAC := macForToken(W, request)
+	if !hmac.Equal(expectedMAC, mac) {
+		return false, errors.New("blind token: MAC verification failed")
+	}
+
+	if !btm.tm.MarkSpentIfNew(tokenSeedKey(seed)) {
+		return false, errors.New("blind token: token already spent")
+	}
+
+	return true, nil
+}
+
+// BlindToken is run by the bidder during issuance. It samples a random
+// token seed t, derives T = HashToCurve(t), and blinds it with a random
+// factor r to produce B = rT, which is all the issuer ever sees.
+func BlindToken() (seed []byte, r *big.Int, B ECPoint, err error) {
+	seed = make([]byte, 32)
+	if _, err = rand.Read(seed); err != nil {
+		return nil, nil, ECPoint{}, fmt.Errorf("blind token: failed to sample seed: %w", err)
+	}
+
+	r, err = randScalar()
+	if err != nil {
+		return nil, nil, ECPoint{}, fmt.Errorf("blind token: failed to sample blinding factor: %w", err)
+	}
+
+	T := HashToCurvePoint(seed)
+	B = scalarMult(T, r)
+	return seed, r, B, nil
+}
+
+// UnblindToken removes the bidder's blinding factor from the issuer's
+// response Z = xB, yielding W = xT = r⁻¹Z without the issuer ever learning r
+// or being able to link B back to seed.
+func UnblindToken(r *big.Int, Z ECPoint) ECPoint {
+	n := blindCurve.Params().N
+	rInv := new(big.Int).ModInverse(r, n)
+	return scalarMult(Z, rInv)
+}
+
+// VerifyDLEQ checks a proof returned by IssueBlindToken against the issuer's
+// public key Y, without needing x.
+func VerifyDLEQ(Y, B, Z ECPoint, proof DLEQProof) bool {
+	if B.X == nil || Z.X == nil || proof.C == nil || proof.S == nil {
+		return false
+	}
+
+	sG := scalarBaseMult(proof.S)
+	cY := scalarMult(Y, proof.C)
+	A1 := pointAdd(sG, pointNeg(cY))
+
+	sB := scalarMult(B, proof.S)
+	cZ := scalarMult(Z, proof.C)
+	A2 := pointAdd(sB, pointNeg(cZ))
+
+	expected := dleqChallenge(Y, B, Z, A1, A2)
+	return expected.Cmp(proof.C) == 0
+}
+
+// HashToCurvePoint deterministically derives a curve point T from an
+// arbitrary seed using try-and-increment: hash seed||counter to a candidate
+// x-coordinate and accept the first one for which x³-3x+B is a quadratic
+// residue mod P, incrementing the counter on failure (about half of
+// candidates succeed, so this terminates almost immediately).
+//
+// This must NOT be done as T = H(seed) mod N · G: that makes T a scalar
+// multiple of G by a scalar anyone can recompute from seed alone, so anyone
+// who knows the issuer's public key Y = xG can derive W = xT = H(seed)·Y
+// themselves without ever calling IssueBlindToken, forging spendable tokens
+// the issuer never signed. Deriving T by hashing to an x-coordinate instead
+// gives it no publicly known relationship to G, so only the issuer's own
+// scalar multiplication Z = xB (run inside IssueBlindToken, or by the
+// issuer directly as W = xT) can produce W.
+func HashToCurvePoint(seed []byte) ECPoint {
+	params := blindCurve.Params()
+	var counterBytes [2]byte
+	for counter := 0; counter < 1<<16; counter++ {
+		binary.BigEndian.PutUint16(counterBytes[:], uint16(counter))
+		h := sha256.Sum256(append(append([]byte{}, seed...), counterBytes[:]...))
+		x := new(big.Int).SetBytes(h[:])
+		x.Mod(x, params.P)
+
+		rhs := curveRHS(x, params)
+		y := new(big.Int).ModSqrt(rhs, params.P)
+		if y != nil && blindCurve.IsOnCurve(x, y) {
+			return ECPoint{X: x, Y: y}
+		}
+	}
+	// Failing to find a valid x-coordinate within 2^16 tries has
+	// probability roughly 2^-65536 and signals something is wrong with
+	// blindCurve's parameters rather than bad luck.
+	panic("blind token: exhausted hash-to-curve candidates")
+}
+
+// curveRHS evaluates x³ - 3x + B mod P, the right-hand side of the
+// short-Weierstrass equation every curve in crypto/elliptic uses (a = -3).
+func curveRHS(x *big.Int, params *elliptic.CurveParams) *big.Int {
+	rhs := new(big.Int).Mul(x, x)
+	rhs.Mul(rhs, x)
+
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+
+	rhs.Sub(rhs, threeX)
+	rhs.Add(rhs, params.B)
+	rhs.Mod(rhs, params.P)
+	return rhs
+}
+
+// IssueBlindToken is the issuer-side half of blind issuance: it signs the
+// bidder's blinded point B with the issuer's private scalar x and proves,
+// via DLEQ, that it used the same key as Y = xG.
+func (bi *BlindIssuer) IssueBlindToken(B ECPoint) (ECPoint, DLEQProof, error) {
+	if B.X == nil || B.Y == nil || !blindCurve.IsOnCurve(B.X, B.Y) {
+		return ECPoint{}, DLEQProof{}, errors.New("blind token: B is not a valid curve point")
+	}
+
+	Z := scalarMult(B, bi.x)
+
+	k, err := randScalar()
+	if err != nil {
+		return ECPoint{}, DLEQProof{}, fmt.Errorf("blind token: failed to sample proof nonce: %w", err)
+	}
+	A1 := scalarBaseMult(k)
+	A2 := scalarMult(B, k)
+
+	c := dleqChallenge(bi.Y, B, Z, A1, A2)
+	n := blindCurve.Params().N
+	s := new(big.Int).Mul(c, bi.x)
+	s.Add(s, k)
+	s.Mod(s, n)
+
+	return Z, DLEQProof{C: c, S: s}, nil
+}
+
+func randScalar() (*big.Int, error) {
+	return rand.Int(rand.Reader, blindCurve.Params().N)
+}
+
+func pointNeg(p ECPoint) ECPoint {
+	params := blindCurve.Params()
+	negY := new(big.Int).Sub(params.P, p.Y)
+	negY.Mod(negY, params.P)
+	return ECPoint{X: p.X, Y: negY}
+}
+
+func pointAdd(a, b ECPoint) ECPoint {
+	x, y := blindCurve.Add(a.X, a.Y, b.X, b.Y)
+	return ECPoint{X: x, Y: y}
+}
+
+func scalarMult(p ECPoint, k *big.Int) ECPoint {
+	kk := new(big.Int).Mod(k, blindCurve.Params().N)
+	x, y := blindCurve.ScalarMult(p.X, p.Y, kk.Bytes())
+	return ECPoint{X: x, Y: y}
+}
+
+func scalarBaseMult(k *big.Int) ECPoint {
+	kk := new(big.Int).Mod(k, blindCurve.Params().N)
+	x, y := blindCurve.ScalarBaseMult(kk.Bytes())
+	return ECPoint{X: x, Y: y}
+}
+
+// dleqChallenge builds the Fiat-Shamir challenge over the full transcript so
+// the issuer and the verifier always hash the same points in the same order.
+func dleqChallenge(Y, B, Z, A1, A2 ECPoint) *big.Int {
+	g := scalarBaseMult(big.NewInt(1))
+
+	h := sha256.New()
+	for _, p := range []ECPoint{g, Y, B, Z, A1, A2} {
+		h.Write(p.X.Bytes())
+		h.Write(p.Y.Bytes())
+	}
+
+	c := new(big.Int).SetBytes(h.Sum(nil))
+	return c.Mod(c, blindCurve.Params().N)
+}
+
+// macForToken derives a per-token MAC key from the unblinded point W = xT and
+// authenticates a redemption request under it, binding the bid submission to
+// the token without W ever being transmitted.
+func macForToken(W ECPoint, request []byte) []byte {
+	key := sha256.Sum256(append(W.X.Bytes(), W.Y.Bytes()...))
+	mac := hmac.New(sha256.New, key[:])
+	mac.Write(request)
+	return mac.Sum(nil)
+}
+
+func tokenSeedKey(seed []byte) string {
+	h := sha256.Sum256(seed)
+	return hex.EncodeToString(h[:])
+}
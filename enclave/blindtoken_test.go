@@ -0,0 +1,132 @@
+package main
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/peterldowns/testy/assert"
+)
+
+func TestBlindToken_IssueRedeemRoundTrip(t *testing.T) {
+	tm := NewTokenManager()
+	btm, err := NewBlindTokenManager(tm)
+	assert.NoError(t, err)
+
+	seed, r, B, err := BlindToken()
+	assert.NoError(t, err)
+
+	Z, proof, err := btm.IssueBlindToken(B)
+	assert.NoError(t, err)
+	assert.True(t, VerifyDLEQ(btm.PublicKey(), B, Z, proof))
+
+	W := UnblindToken(r, Z)
+
+	request := []byte(`{"auction_token_request":"bid-1"}`)
+	mac := macForToken(W, request)
+
+	ok, err := btm.VerifyAndConsumeBlindToken(seed, mac, request)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestBlindToken_ReplayRejected(t *testing.T) {
+	tm := NewTokenManager()
+	btm, err := NewBlindTokenManager(tm)
+	assert.NoError(t, err)
+
+	seed, r, B, err := BlindToken()
+	assert.NoError(t, err)
+
+	Z, _, err := btm.IssueBlindToken(B)
+	assert.NoError(t, err)
+	W := UnblindToken(r, Z)
+
+	request := []byte("bid-payload")
+	mac := macForToken(W, request)
+
+	ok, err := btm.VerifyAndConsumeBlindToken(seed, mac, request)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Redeeming the same seed again must fail even with a valid MAC.
+	ok, err = btm.VerifyAndConsumeBlindToken(seed, mac, request)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+}
+
+func TestBlindToken_TamperedMACRejected(t *testing.T) {
+	tm := NewTokenManager()
+	btm, err := NewBlindTokenManager(tm)
+	assert.NoError(t, err)
+
+	seed, r, B, err := BlindToken()
+	assert.NoError(t, err)
+
+	Z, _, err := btm.IssueBlindToken(B)
+	assert.NoError(t, err)
+	W := UnblindToken(r, Z)
+
+	mac := macForToken(W, []byte("original-request"))
+
+	ok, err := btm.VerifyAndConsumeBlindToken(seed, mac, []byte("tampered-request"))
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+}
+
+func TestBlindToken_DLEQFailsForWrongIssuer(t *testing.T) {
+	tm := NewTokenManager()
+	btm, err := NewBlindTokenManager(tm)
+	assert.NoError(t, err)
+
+	otherIssuer, err := NewBlindIssuer()
+	assert.NoError(t, err)
+
+	_, _, B, err := BlindToken()
+	assert.NoError(t, err)
+
+	Z, proof, err := btm.IssueBlindToken(B)
+	assert.NoError(t, err)
+
+	// The proof was built with btm's key; it must not verify against a
+	// different issuer's public key.
+	assert.False(t, VerifyDLEQ(otherIssuer.Y, B, Z, proof))
+}
+
+func TestHashToCurvePoint_NotAPublicScalarMultipleOfG(t *testing.T) {
+	// If HashToCurvePoint still derived T as scalar·G for a publicly
+	// computable scalar, anyone holding the issuer's public key Y = xG
+	// could forge W = xT = scalar·Y directly, without the issuer ever
+	// running IssueBlindToken. Guard against regressing to that shape: the
+	// scalar that HashToCurvePoint's old (broken) construction would have
+	// used, SHA256(seed) mod N, must not in fact be T's discrete log.
+	tm := NewTokenManager()
+	btm, err := NewBlindTokenManager(tm)
+	assert.NoError(t, err)
+
+	seed := []byte("attacker-chosen-seed")
+	T := HashToCurvePoint(seed)
+
+	n := blindCurve.Params().N
+	h := sha256.Sum256(seed)
+	forgedScalar := new(big.Int).Mod(new(big.Int).SetBytes(h[:]), n)
+	forgedW := scalarMult(btm.PublicKey(), forgedScalar)
+
+	// The legitimate W for this seed, derived through real issuance.
+	r, err := randScalar()
+	assert.NoError(t, err)
+	B := scalarMult(T, r)
+	Z, _, err := btm.IssueBlindToken(B)
+	assert.NoError(t, err)
+	realW := UnblindToken(r, Z)
+
+	assert.False(t, forgedW.X.Cmp(realW.X) == 0 && forgedW.Y.Cmp(realW.Y) == 0)
+}
+
+func TestTokenManager_MarkSpentIfNew(t *testing.T) {
+	tm := NewTokenManager()
+
+	assert.True(t, tm.MarkSpentIfNew("key-a"))
+	assert.False(t, tm.MarkSpentIfNew("key-a"))
+	assert.True(t, tm.MarkSpentIfNew("key-b"))
+}
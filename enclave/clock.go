@@ -0,0 +1,7 @@
+package main
+
+import "time"
+
+func timeNowReal() int64 {
+	return time.Now().UnixNano()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/cloudx-io/openauction/crypto/keystores"
+)
+
+// KeyManager is the enclave's key source. It embeds the rotating RSA
+// keystore so existing callers that construct a *KeyManager and read its
+// PublicKey field (or call CurrentKey/GetByID/Rotate) keep working
+// unchanged, and adds a single long-term P-256 ECDH keypair for bidders who
+// seal their price with ECIES instead of RSA-OAEP.
+type KeyManager struct {
+	*keystores.MemoryKeyStore
+	ECIESPrivateKey *ecdh.PrivateKey
+	ECIESPublicKey  *ecdh.PublicKey
+}
+
+// NewKeyManager creates a KeyManager with a freshly-generated RSA-2048 key
+// and a freshly-generated P-256 ECDH key, each the current key for its
+// respective scheme.
+func NewKeyManager() (*KeyManager, error) {
+	rsaStore, err := keystores.NewMemoryKeyStore()
+	if err != nil {
+		return nil, err
+	}
+
+	eciesKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("enclave: failed to generate ECIES key pair: %w", err)
+	}
+
+	return &KeyManager{
+		MemoryKeyStore:  rsaStore,
+		ECIESPrivateKey: eciesKey,
+		ECIESPublicKey:  eciesKey.PublicKey(),
+	}, nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/cloudx-io/openauction/enclaveapi"
+)
+
+// nowFunc lets tests drive virtual time instead of sleeping; production
+// code always uses the real clock.
+var nowFunc = timeNowReal
+
+// tokenBucket is a classic token bucket: it refills at RatePerSecond tokens
+// per second up to Burst, and Allow consumes one token if available.
+type tokenBucket struct {
+	mu sync.Mutex
+
+	ratePerSecond float64
+	burst         float64
+	tokens        float64
+	lastRefill    int64 // unix nanoseconds, per nowFunc
+}
+
+func newTokenBucket(ratePerSecond, burst float64) *tokenBucket {
+	return &tokenBucket{
+		ratePerSecond: ratePerSecond,
+		burst:         burst,
+		tokens:        burst,
+		lastRefill:    nowFunc(),
+	}
+}
+
+// Allow reports whether a request may proceed right now, consuming one
+// token if so.
+func (b *tokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := nowFunc()
+	elapsedSeconds := float64(now-b.lastRefill) / 1e9
+	if elapsedSeconds > 0 {
+		b.tokens = minFloat(b.burst, b.tokens+elapsedSeconds*b.ratePerSecond)
+		b.lastRefill = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// RateLimitConfig configures RateLimiter. RatePerSecond/Burst are the
+// default per-bidder bucket; PerBidderOverrides lets specific bidders (or,
+// under mTLS, cert subjects) get a different bucket; GlobalRatePerSecond/
+// GlobalBurst cap aggregate decrypt throughput across every bidder so one
+// greedy bidder can't starve the rest.
+type RateLimitConfig struct {
+	RatePerSecond float64
+	Burst         float64
+
+	PerBidderOverrides map[string]BidderRateLimit
+
+	GlobalRatePerSecond float64
+	GlobalBurst         float64
+}
+
+// BidderRateLimit overrides the default bucket for one bidder identity.
+type BidderRateLimit struct {
+	RatePerSecond float64
+	Burst         float64
+}
+
+// RateLimitMetrics counts what RateLimiter decided, so operators can tell a
+// quiet auction from one that's silently throttling everyone.
+type RateLimitMetrics struct {
+	mu          sync.Mutex
+	Accepted    uint64
+	Throttled   uint64
+	DecryptFail uint64
+}
+
+func (m *RateLimitMetrics) recordAccepted() {
+	m.mu.Lock()
+	m.Accepted++
+	m.mu.Unlock()
+}
+
+func (m *RateLimitMetrics) recordThrottled() {
+	m.mu.Lock()
+	m.Throttled++
+	m.mu.Unlock()
+}
+
+// RecordDecryptFailure lets callers outside this package (e.g. the bid
+// ingress handler, after calling decryptAllBids) report a decrypt failure
+// against the same metrics as throttling decisions.
+func (m *RateLimitMetrics) RecordDecryptFailure() {
+	m.mu.Lock()
+	m.DecryptFail++
+	m.mu.Unlock()
+}
+
+// RateLimiter enforces a per-bidder token bucket plus a shared global
+// bucket on bid ingress, rejecting a bid before the CPU-dominant RSA-OAEP/
+// ECIES decrypt in decryptAllBids ever runs for it.
+type RateLimiter struct {
+	mu      sync.Mutex
+	cfg     RateLimitConfig
+	buckets map[string]*tokenBucket
+	global  *tokenBucket
+
+	Metrics RateLimitMetrics
+}
+
+// NewRateLimiter builds a RateLimiter from cfg. A zero-value
+// GlobalRatePerSecond/GlobalBurst disables the global bucket.
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	rl := &RateLimiter{
+		cfg:     cfg,
+		buckets: make(map[string]*tokenBucket),
+	}
+	if cfg.GlobalRatePerSecond > 0 {
+		rl.global = newTokenBucket(cfg.GlobalRatePerSecond, cfg.GlobalBurst)
+	}
+	return rl
+}
+
+// Allow reports whether a bid from identity may proceed, consuming a token
+// from both that bidder's bucket and the global bucket (if configured).
+// identity is a bidder ID normally, or the mTLS client cert subject when
+// TLS client auth is enabled.
+func (rl *RateLimiter) Allow(identity string) bool {
+	bucket := rl.bucketFor(identity)
+	if !bucket.Allow() {
+		rl.Metrics.recordThrottled()
+		return false
+	}
+
+	// Check the per-bidder bucket first: charging the global bucket before
+	// knowing the per-bidder check will pass would let a bidder who's only
+	// over their own limit drain the shared bucket with every rejection,
+	// starving everyone else.
+	if rl.global != nil && !rl.global.Allow() {
+		rl.Metrics.recordThrottled()
+		return false
+	}
+
+	rl.Metrics.recordAccepted()
+	return true
+}
+
+func (rl *RateLimiter) bucketFor(identity string) *tokenBucket {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if bucket, ok := rl.buckets[identity]; ok {
+		return bucket
+	}
+
+	rate, burst := rl.cfg.RatePerSecond, rl.cfg.Burst
+	if override, ok := rl.cfg.PerBidderOverrides[identity]; ok {
+		rate, burst = override.RatePerSecond, override.Burst
+	}
+
+	bucket := newTokenBucket(rate, burst)
+	rl.buckets[identity] = bucket
+	return bucket
+}
+
+// rateLimitBids drops bids from bidders over their rate limit before
+// decryptAllBids runs, since RSA-OAEP/ECIES decryption is the CPU-dominant
+// op per bid and is the natural thing to protect from a flood. identityFor
+// picks the identity a bid is rate-limited under: normally the bidder ID,
+// but the mTLS client cert subject when TLS client auth is enabled.
+func rateLimitBids(rl *RateLimiter, encBids []enclaveapi.EncryptedCoreBid, identityFor func(enclaveapi.EncryptedCoreBid) string) (allowed []enclaveapi.EncryptedCoreBid, throttled []ExcludedBid) {
+	if rl == nil {
+		return encBids, nil
+	}
+
+	for _, bid := range encBids {
+		identity := bid.Bidder
+		if identityFor != nil {
+			identity = identityFor(bid)
+		}
+
+		if !rl.Allow(identity) {
+			throttled = append(throttled, ExcludedBid{
+				BidID:  bid.ID,
+				Bidder: bid.Bidder,
+				Reason: "rate limit exceeded",
+			})
+			continue
+		}
+		allowed = append(allowed, bid)
+	}
+	return allowed, throttled
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/peterldowns/testy/assert"
+
+	"github.com/cloudx-io/openauction/core"
+	"github.com/cloudx-io/openauction/enclaveapi"
+)
+
+// virtualClock lets a test advance nowFunc deterministically instead of
+// sleeping for real wall-clock time.
+type virtualClock struct {
+	mu  sync.Mutex
+	now int64
+}
+
+func (c *virtualClock) now_() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *virtualClock) Advance(d int64) {
+	c.mu.Lock()
+	c.now += d
+	c.mu.Unlock()
+}
+
+// withVirtualClock swaps nowFunc for the duration of a test and restores it
+// afterward, so one test's clock never leaks into the next. nowFunc is a
+// package-level variable, so the swap is not isolated between parallel tests.
+func withVirtualClock(t *testing.T) *virtualClock {
+	t.Helper()
+	clock := &virtualClock{now: 1_000_000_000}
+	original := nowFunc
+	nowFunc = clock.now_
+	t.Cleanup(func() { nowFunc = original })
+	return clock
+}
+
+const second = int64(1e9)
+
+func TestTokenBucket_AllowsBurstThenThrottles(t *testing.T) {
+	withVirtualClock(t)
+
+	bucket := newTokenBucket(1, 3) // 1/sec, burst of 3
+	assert.True(t, bucket.Allow())
+	assert.True(t, bucket.Allow())
+	assert.True(t, bucket.Allow())
+	assert.False(t, bucket.Allow()) // burst exhausted
+}
+
+func TestTokenBucket_RefillsOverVirtualTime(t *testing.T) {
+	clock := withVirtualClock(t)
+
+	bucket := newTokenBucket(1, 1) // 1/sec, burst of 1
+	assert.True(t, bucket.Allow())
+	assert.False(t, bucket.Allow())
+
+	clock.Advance(second)
+	assert.True(t, bucket.Allow())
+}
+
+func TestRateLimiter_PerBidderBucketsAreIndependent(t *testing.T) {
+	withVirtualClock(t)
+
+	rl := NewRateLimiter(RateLimitConfig{RatePerSecond: 1, Burst: 1})
+	assert.True(t, rl.Allow("bidder1"))
+	assert.False(t, rl.Allow("bidder1"))
+	assert.True(t, rl.Allow("bidder2")) // separate bucket, unaffected
+}
+
+func TestRateLimiter_PerBidderOverride(t *testing.T) {
+	withVirtualClock(t)
+
+	rl := NewRateLimiter(RateLimitConfig{
+		RatePerSecond: 1, Burst: 1,
+		PerBidderOverrides: map[string]BidderRateLimit{
+			"vip": {RatePerSecond: 1, Burst: 5},
+		},
+	})
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, rl.Allow("vip"))
+	}
+	assert.False(t, rl.Allow("vip"))
+}
+
+func TestRateLimiter_GlobalBucketCapsAggregateThroughput(t *testing.T) {
+	withVirtualClock(t)
+
+	rl := NewRateLimiter(RateLimitConfig{
+		RatePerSecond: 100, Burst: 100, // generous per-bidder limits
+		GlobalRatePerSecond: 1, GlobalBurst: 1,
+	})
+
+	assert.True(t, rl.Allow("bidder1"))
+	assert.False(t, rl.Allow("bidder2")) // global bucket exhausted, even though bidder2 never used it
+}
+
+func TestRateLimiter_PerBidderRejectionDoesNotDrainGlobalBucket(t *testing.T) {
+	withVirtualClock(t)
+
+	rl := NewRateLimiter(RateLimitConfig{
+		RatePerSecond: 1, Burst: 1, // bidder1 exhausts this immediately
+		GlobalRatePerSecond: 1, GlobalBurst: 5,
+	})
+
+	assert.True(t, rl.Allow("bidder1"))
+	for i := 0; i < 3; i++ {
+		assert.False(t, rl.Allow("bidder1")) // over its own limit, not the global one
+	}
+
+	// The global bucket should still have tokens left for other bidders,
+	// since bidder1's rejections never should have charged it.
+	assert.True(t, rl.Allow("bidder2"))
+	assert.True(t, rl.Allow("bidder3"))
+}
+
+func TestRateLimiter_MetricsCountAcceptedAndThrottled(t *testing.T) {
+	withVirtualClock(t)
+
+	rl := NewRateLimiter(RateLimitConfig{RatePerSecond: 1, Burst: 1})
+	assert.True(t, rl.Allow("bidder1"))
+	assert.False(t, rl.Allow("bidder1"))
+
+	assert.Equal(t, uint64(1), rl.Metrics.Accepted)
+	assert.Equal(t, uint64(1), rl.Metrics.Throttled)
+}
+
+func TestRateLimiter_RecordDecryptFailure(t *testing.T) {
+	rl := NewRateLimiter(RateLimitConfig{RatePerSecond: 1, Burst: 1})
+	rl.Metrics.RecordDecryptFailure()
+	assert.Equal(t, uint64(1), rl.Metrics.DecryptFail)
+}
+
+func TestRateLimitBids_DropsBidsOverLimit(t *testing.T) {
+	withVirtualClock(t)
+
+	rl := NewRateLimiter(RateLimitConfig{RatePerSecond: 1, Burst: 1})
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1"}},
+		{CoreBid: core.CoreBid{ID: "bid2", Bidder: "bidder1"}},
+		{CoreBid: core.CoreBid{ID: "bid3", Bidder: "bidder2"}},
+	}
+
+	allowed, throttled := rateLimitBids(rl, encBids, nil)
+	assert.Equal(t, 2, len(allowed))
+	assert.Equal(t, "bid1", allowed[0].ID)
+	assert.Equal(t, "bid3", allowed[1].ID)
+
+	assert.Equal(t, 1, len(throttled))
+	assert.Equal(t, "bid2", throttled[0].BidID)
+}
+
+func TestRateLimitBids_NilLimiterAllowsEverything(t *testing.T) {
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1"}},
+	}
+	allowed, throttled := rateLimitBids(nil, encBids, nil)
+	assert.Equal(t, 1, len(allowed))
+	assert.Equal(t, 0, len(throttled))
+}
+
+func TestRateLimitBids_IdentityForOverridesBidder(t *testing.T) {
+	withVirtualClock(t)
+
+	rl := NewRateLimiter(RateLimitConfig{RatePerSecond: 1, Burst: 1})
+	encBids := []enclaveapi.EncryptedCoreBid{
+		{CoreBid: core.CoreBid{ID: "bid1", Bidder: "bidder1"}},
+		{CoreBid: core.CoreBid{ID: "bid2", Bidder: "bidder2"}},
+	}
+
+	// Both bids map to the same mTLS cert subject, so the second is
+	// throttled even though the bidders differ.
+	allowed, throttled := rateLimitBids(rl, encBids, func(enclaveapi.EncryptedCoreBid) string { return "cert-subject-x" })
+	assert.Equal(t, 1, len(allowed))
+	assert.Equal(t, 1, len(throttled))
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSMode selects how EnclaveServer terminates TLS for incoming bid
+// connections.
+type TLSMode int
+
+const (
+	// TLSDisabled serves plaintext; the original, and still default,
+	// behavior.
+	TLSDisabled TLSMode = iota
+	// TLSStatic serves a fixed certificate/key pair, optionally requiring a
+	// client certificate signed by one of ClientCAs (mTLS).
+	TLSStatic
+	// TLSAutocert issues and renews certificates automatically via ACME.
+	TLSAutocert
+)
+
+// TLSConfig configures EnclaveServer's TLS termination. Mode selects which
+// of the fields below apply; the rest are ignored.
+type TLSConfig struct {
+	Mode TLSMode
+
+	// CertFile and KeyFile are used when Mode is TLSStatic.
+	CertFile string
+	KeyFile  string
+	// ClientCAs, when non-empty under TLSStatic, enables mTLS: only
+	// connections presenting a certificate signed by one of these PEM files
+	// are accepted. The verified client cert's subject is then available to
+	// handlers via ClientCertSubject, so the token manager can bind
+	// consumed tokens to a bidder identity.
+	ClientCAs []string
+
+	// CacheDir, AllowedHosts, and FallbackCert are used when Mode is
+	// TLSAutocert. AllowedHosts restricts which hostnames autocert will
+	// request a certificate for, so a misconfigured DNS record can't be
+	// used to make the enclave fetch arbitrary certificates.
+	CacheDir     string
+	AllowedHosts []string
+	// FallbackCert, if set, is served whenever autocert fails to supply a
+	// certificate for a handshake (for any reason, e.g. ACME issuance
+	// failing after a cold start), instead of failing that handshake.
+	FallbackCert *tls.Certificate
+}
+
+// ServerConfig configures EnclaveServer beyond just its listen port.
+type ServerConfig struct {
+	Port uint32
+	TLS  TLSConfig
+}
+
+// NewEnclaveServerWithConfig builds an EnclaveServer with the given TLS
+// mode. NewEnclaveServer remains the plaintext entry point so existing
+// callers are unaffected.
+func NewEnclaveServerWithConfig(cfg ServerConfig) (*EnclaveServer, error) {
+	tlsConfig, err := buildTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	return &EnclaveServer{port: cfg.Port, tlsConfig: tlsConfig}, nil
+}
+
+// Listen opens a TCP listener on s.port, wrapped in TLS if s was built with
+// a TLS mode other than TLSDisabled.
+func (s *EnclaveServer) Listen() (net.Listener, error) {
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return nil, fmt.Errorf("enclave: failed to listen on port %d: %w", s.port, err)
+	}
+	if s.tlsConfig == nil {
+		return ln, nil
+	}
+	return tls.NewListener(ln, s.tlsConfig), nil
+}
+
+// ClientCertSubject returns the verified client certificate's subject
+// common name from an mTLS connection, or "" if no client certificate was
+// presented (plaintext, TLSStatic without ClientCAs, or TLSAutocert, none
+// of which request one).
+func ClientCertSubject(state tls.ConnectionState) string {
+	if len(state.PeerCertificates) == 0 {
+		return ""
+	}
+	return state.PeerCertificates[0].Subject.CommonName
+}
+
+func buildTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	switch cfg.Mode {
+	case TLSDisabled:
+		return nil, nil
+	case TLSStatic:
+		return buildStaticTLSConfig(cfg)
+	case TLSAutocert:
+		return buildAutocertTLSConfig(cfg)
+	default:
+		return nil, fmt.Errorf("enclave: unknown TLS mode %d", cfg.Mode)
+	}
+}
+
+func buildStaticTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("enclave: failed to load TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if len(cfg.ClientCAs) == 0 {
+		return tlsConfig, nil
+	}
+
+	pool := x509.NewCertPool()
+	for _, path := range cfg.ClientCAs {
+		pemBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("enclave: failed to read client CA %q: %w", path, err)
+		}
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("enclave: failed to parse client CA %q", path)
+		}
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return tlsConfig, nil
+}
+
+// buildAutocertTLSConfig wires an autocert.Manager into a *tls.Config.
+// Certificate renewal happens in the background inside GetCertificate: it's
+// checked (and refreshed from Cache or ACME if needed) on every handshake,
+// so there's no separate renewal goroutine to manage here, and a renewed
+// cert is picked up by the very next connection with no listener restart.
+func buildAutocertTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	if len(cfg.AllowedHosts) == 0 {
+		return nil, fmt.Errorf("enclave: autocert requires at least one allowed host")
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.AllowedHosts...),
+		Cache:      autocert.DirCache(cfg.CacheDir),
+	}
+
+	tlsConfig := manager.TLSConfig()
+	if cfg.FallbackCert != nil {
+		issue := tlsConfig.GetCertificate
+		tlsConfig.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, err := issue(hello)
+			if err != nil {
+				return cfg.FallbackCert, nil
+			}
+			return cert, nil
+		}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/peterldowns/testy/assert"
+)
+
+// selfSignedCert generates a self-signed certificate/key pair for subject
+// and writes them as PEM files under dir, returning their paths.
+func selfSignedCert(t *testing.T, dir, name, subject string) (certPath, keyPath string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: subject},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	assert.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	assert.NoError(t, err)
+
+	certPath = filepath.Join(dir, name+"-cert.pem")
+	keyPath = filepath.Join(dir, name+"-key.pem")
+
+	assert.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certPath, keyPath
+}
+
+func TestBuildTLSConfig_Disabled(t *testing.T) {
+	tlsConfig, err := buildTLSConfig(TLSConfig{Mode: TLSDisabled})
+	assert.NoError(t, err)
+	assert.Equal(t, (*tls.Config)(nil), tlsConfig)
+}
+
+func TestBuildTLSConfig_Static(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := selfSignedCert(t, dir, "server", "enclave.test")
+
+	tlsConfig, err := buildTLSConfig(TLSConfig{Mode: TLSStatic, CertFile: certPath, KeyFile: keyPath})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(tlsConfig.Certificates))
+	assert.Equal(t, tls.ClientAuthType(0), tlsConfig.ClientAuth) // no mTLS requested
+}
+
+func TestBuildTLSConfig_StaticWithClientCAs(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := selfSignedCert(t, dir, "server", "enclave.test")
+	caCertPath, _ := selfSignedCert(t, dir, "client-ca", "bidder-ca.test")
+
+	tlsConfig, err := buildTLSConfig(TLSConfig{
+		Mode:      TLSStatic,
+		CertFile:  certPath,
+		KeyFile:   keyPath,
+		ClientCAs: []string{caCertPath},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+}
+
+func TestBuildTLSConfig_StaticMissingFileFails(t *testing.T) {
+	_, err := buildTLSConfig(TLSConfig{Mode: TLSStatic, CertFile: "does-not-exist.pem", KeyFile: "does-not-exist.pem"})
+	assert.NotNil(t, err)
+}
+
+func TestBuildTLSConfig_AutocertRequiresAllowedHosts(t *testing.T) {
+	_, err := buildTLSConfig(TLSConfig{Mode: TLSAutocert, CacheDir: t.TempDir()})
+	assert.NotNil(t, err)
+}
+
+func TestBuildTLSConfig_Autocert(t *testing.T) {
+	tlsConfig, err := buildTLSConfig(TLSConfig{
+		Mode:         TLSAutocert,
+		CacheDir:     t.TempDir(),
+		AllowedHosts: []string{"enclave.example.com"},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, tlsConfig.GetCertificate)
+}
+
+func TestClientCertSubject_NoPeerCertificate(t *testing.T) {
+	assert.Equal(t, "", ClientCertSubject(tls.ConnectionState{}))
+}
+
+func TestClientCertSubject_ReturnsCommonName(t *testing.T) {
+	dir := t.TempDir()
+	certPath, _ := selfSignedCert(t, dir, "bidder", "bidder-42")
+
+	pemBytes, err := os.ReadFile(certPath)
+	assert.NoError(t, err)
+	block, _ := pem.Decode(pemBytes)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	assert.Equal(t, "bidder-42", ClientCertSubject(state))
+}
+
+func TestNewEnclaveServerWithConfig_Plaintext(t *testing.T) {
+	server, err := NewEnclaveServerWithConfig(ServerConfig{Port: 9443})
+	assert.NoError(t, err)
+	assert.Equal(t, (*tls.Config)(nil), server.tlsConfig)
+}
@@ -3,11 +3,15 @@ package main
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/ecdh"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+
+	pkgcrypto "github.com/cloudx-io/openauction/crypto"
 )
 
 // HybridEncryptionResult contains the results of hybrid encryption
@@ -60,3 +64,42 @@ func EncryptHybrid(plaintext []byte, publicKey *rsa.PublicKey) (*HybridEncryptio
 		Nonce:            base64.StdEncoding.EncodeToString(nonceBytes),
 	}, nil
 }
+
+// ECIESEncryptionResult contains the results of ECIES encryption.
+type ECIESEncryptionResult struct {
+	EphemeralPublicKey string
+	EncryptedPayload   string
+	Nonce              string
+}
+
+// eciesJSONEnvelope mirrors the unexported envelope shape
+// pkgcrypto.ECIESAlgorithm marshals, so EncryptECIES can unpack the fields a
+// test bid needs without that package exporting its internal wire type.
+type eciesJSONEnvelope struct {
+	EphemeralPublicKey string `json:"ephemeral_public_key"`
+	EncryptedPayload   string `json:"encrypted_payload"`
+	Nonce              string `json:"nonce"`
+}
+
+// EncryptECIES encrypts data using ECIES over P-256, calling
+// pkgcrypto.ECIESAlgorithm's Encrypt directly (the same code crypto.Engine
+// dispatches the ECIES tag to). This is for
+// testing purposes only - it simulates what bidders without RSA tooling
+// will do in production.
+func EncryptECIES(plaintext []byte, peerPublicKey *ecdh.PublicKey) (*ECIESEncryptionResult, error) {
+	envelopeJSON, err := pkgcrypto.ECIESAlgorithm{}.Encrypt(plaintext, peerPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var env eciesJSONEnvelope
+	if err := json.Unmarshal(envelopeJSON, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse ECIES envelope: %w", err)
+	}
+
+	return &ECIESEncryptionResult{
+		EphemeralPublicKey: env.EphemeralPublicKey,
+		EncryptedPayload:   env.EncryptedPayload,
+		Nonce:              env.Nonce,
+	}, nil
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/cloudx-io/openauction/core/audit"
 )
 
 // AuctionToken represents a single-use token for bid replay protection
@@ -19,7 +21,9 @@ type AuctionToken struct {
 // Uses sync.Map for fine-grained concurrency without global lock contention
 // so that we are not contending on a single lock when processing multiple bids or multiple auctions at once.
 type TokenManager struct {
-	tokens sync.Map // map[string]*AuctionToken with lock-free reads
+	tokens   sync.Map // map[string]*AuctionToken with lock-free reads
+	spent    sync.Map // blind-token keys already redeemed; never expired or consumed
+	auditLog *audit.Log
 }
 
 // NewTokenManager creates a new TokenManager
@@ -27,6 +31,13 @@ func NewTokenManager() *TokenManager {
 	return &TokenManager{}
 }
 
+// SetAuditLog attaches an audit transcript that GenerateToken and
+// ValidateAndConsumeToken will write to. Passing nil (the default) disables
+// auditing.
+func (tm *TokenManager) SetAuditLog(log *audit.Log) {
+	tm.auditLog = log
+}
+
 // GenerateToken creates a new auction token using cryptographically secure randomness.
 //
 // uuid.New() uses crypto/rand internally, which calls the getrandom syscall to obtain
@@ -43,6 +54,10 @@ func (tm *TokenManager) GenerateToken() string {
 		CreatedAt: time.Now(),
 	})
 
+	if tm.auditLog != nil {
+		tm.auditLog.Append(audit.KindTokenIssued, []byte(tokenID))
+	}
+
 	return tokenID
 }
 
@@ -68,9 +83,24 @@ func (tm *TokenManager) ValidateAndConsumeToken(tokenID string) bool {
 	}
 
 	_, existed := tm.tokens.LoadAndDelete(tokenID)
+	if existed && tm.auditLog != nil {
+		tm.auditLog.Append(audit.KindBidReceived, []byte(tokenID))
+	}
 	return existed
 }
 
+// MarkSpentIfNew atomically records key as spent and reports whether this
+// was its first use. It backs blind-token redemption (see
+// BlindTokenManager.VerifyAndConsumeBlindToken), where unlike UUID tokens
+// there is no issuance-time registration to consume: the first redemption is
+// what establishes the token as spent, and every later one must fail.
+// Spent keys live in their own set, apart from the UUID tokens, so
+// RemoveExpired and ValidateAndConsumeToken never drop them.
+func (tm *TokenManager) MarkSpentIfNew(key string) bool {
+	_, loaded := tm.spent.LoadOrStore(key, struct{}{})
+	return !loaded
+}
+
 // ConsumeToken removes a token from the store (marks it as used)
 func (tm *TokenManager) ConsumeToken(tokenID string) {
 	tm.tokens.Delete(tokenID)
@@ -1,9 +1,19 @@
 package main
 
+import (
+	"crypto/tls"
+
+	"github.com/cloudx-io/openauction/crypto/keystores"
+)
+
 type EnclaveServer struct {
 	port         uint32
-	keyManager   *KeyManager
+	keyManager   keystores.KeyStore
 	tokenManager *TokenManager
+	// tlsConfig is nil for plaintext serving (TLSDisabled), which is what
+	// NewEnclaveServer still builds; NewEnclaveServerWithConfig is the only
+	// way to get TLSStatic or TLSAutocert.
+	tlsConfig *tls.Config
 }
 
 func NewEnclaveServer(port uint32) *EnclaveServer {
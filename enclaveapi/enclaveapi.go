@@ -0,0 +1,16 @@
+// Package enclaveapi defines the wire shapes bidders and the auction
+// coordinator exchange with the enclave, as distinct from core's internal
+// ranking/encryption types so the enclave's request/response schema can
+// evolve without forcing a change to core.
+package enclaveapi
+
+import "github.com/cloudx-io/openauction/core"
+
+// EncryptedCoreBid is a CoreBid whose price has not yet been decrypted. If
+// EncryptedPrice is nil, CoreBid.Price is already in the clear (a bidder
+// that opted out of sealed pricing); otherwise CoreBid.Price must be
+// ignored in favor of the price recovered from EncryptedPrice.
+type EncryptedCoreBid struct {
+	core.CoreBid
+	EncryptedPrice *core.EncryptedBidPrice `json:"encrypted_price,omitempty"`
+}